@@ -0,0 +1,99 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"fmt"
+)
+
+// Level is the severity of an alert sent with SendAlert.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// AlertOption configures SendAlert.
+type AlertOption func(*alertOptions)
+
+type alertOptions struct {
+	colors map[Level]Color
+}
+
+// WithLevelColor sets the border color of alerts of level, replacing the
+// default of blue for LevelInfo, yellow for LevelWarn and red for LevelError.
+func WithLevelColor(level Level, color Color) AlertOption {
+	return func(o *alertOptions) {
+		o.colors[level] = color
+	}
+}
+
+// SendAlert shows text centered inside a border in the color of level, i.e.
+// red for an error. The text is word wrapped in the 4 rows of 20 characters
+// inside the border, longer text returns ErrMessageTruncated. Characters are
+// handled according to the sanitize mode of the client.
+func (c *RWClient) SendAlert(ctx context.Context, level Level, text string, opts ...AlertOption) error {
+	o := &alertOptions{
+		colors: map[Level]Color{
+			LevelInfo:  ColorBlue,
+			LevelWarn:  ColorYellow,
+			LevelError: ColorRed,
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	color, ok := o.colors[level]
+	if !ok {
+		return fmt.Errorf("no color for alert level %v: %w", level, ErrInvalidColor)
+	}
+
+	text, err := c.prepareText(text)
+	if err != nil {
+		return err
+	}
+	co := &composeOptions{width: Columns - 2}
+	lines, err := co.lines(text)
+	if err != nil {
+		return err
+	}
+	if len(lines) > Rows-2 {
+		return fmt.Errorf("alert needs %d rows, the maximum is %d: %w", len(lines), Rows-2, ErrMessageTruncated)
+	}
+	l, err := renderLines(lines, co)
+	if err != nil {
+		return err
+	}
+	if l, err = l.Border(color, 1); err != nil {
+		return err
+	}
+	_, err = c.SendMessage(ctx, l)
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSendAlert(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		level Level
+		opts  []AlertOption
+		want  Color
+	}{
+		{name: "info", level: LevelInfo, want: ColorBlue},
+		{name: "warn", level: LevelWarn, want: ColorYellow},
+		{name: "error", level: LevelError, want: ColorRed},
+		{name: "override", level: LevelWarn, opts: []AlertOption{WithLevelColor(LevelWarn, ColorOrange)}, want: ColorOrange},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &boardHandler{t: t}
+			c := newRWTestClient(t, h.ServeHTTP)
+			if err := c.SendAlert(context.Background(), tc.level, "disk full", tc.opts...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			posted := h.Posted()
+			if len(posted) != 1 {
+				t.Fatalf("want 1 send, got %d", len(posted))
+			}
+			want, err := Compose("disk full")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, err = want.Border(tc.want, 1); err != nil {
+				t.Fatal(err)
+			}
+			if posted[0] != want {
+				t.Errorf("wrong layout:\n%v", posted[0])
+			}
+		})
+	}
+}
+
+func TestSendAlertErrors(t *testing.T) {
+	t.Parallel()
+
+	h := &boardHandler{t: t}
+	c := newRWTestClient(t, h.ServeHTTP)
+	ctx := context.Background()
+
+	// The text is wrapped inside the border, 20 columns wide.
+	if err := c.SendAlert(ctx, LevelInfo, strings.Repeat("X", Columns-1)); !errors.Is(err, ErrWordTooLong) {
+		t.Errorf("want error %v, got: %v", ErrWordTooLong, err)
+	}
+	if err := c.SendAlert(ctx, LevelInfo, "1\n2\n3\n4\n5"); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("want error %v, got: %v", ErrMessageTruncated, err)
+	}
+	if err := c.SendAlert(ctx, Level(7), "hi"); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("want error %v, got: %v", ErrInvalidColor, err)
+	}
+	if err := c.SendAlert(ctx, LevelInfo, "hi", WithLevelColor(LevelInfo, Black)); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("want error %v, got: %v", ErrInvalidColor, err)
+	}
+	if err := c.SendAlert(ctx, LevelInfo, " "); !errors.Is(err, ErrEmptyMessage) {
+		t.Errorf("want error %v, got: %v", ErrEmptyMessage, err)
+	}
+	if n := len(h.Posted()); n != 0 {
+		t.Errorf("invalid alerts were sent, got %d sends", n)
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrAnimationTooLong = errors.New("animation too long")
+
+// MarqueeOption configures Marquee.
+type MarqueeOption func(*marqueeOptions)
+
+type marqueeOptions struct {
+	step     int
+	leading  int
+	trailing int
+}
+
+// WithStep sets how many columns the text moves per frame, the default is 1.
+// For ScrollVertical it is the number of rows.
+func WithStep(n int) MarqueeOption {
+	return func(o *marqueeOptions) {
+		o.step = n
+	}
+}
+
+// WithPadding sets the number of blank cells before and after the text. The
+// default is a full row on either side, so the text scrolls in from the right
+// and all the way out on the left. For ScrollVertical the padding is in rows
+// above and below the text and defaults to a full board.
+func WithPadding(leading, trailing int) MarqueeOption {
+	return func(o *marqueeOptions) {
+		o.leading = leading
+		o.trailing = trailing
+	}
+}
+
+// newMarqueeOptions applies opts with padding cells before and after the
+// text by default.
+func newMarqueeOptions(opts []MarqueeOption, padding int) *marqueeOptions {
+	o := &marqueeOptions{
+		step:     1,
+		leading:  padding,
+		trailing: padding,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.step < 1 {
+		o.step = 1
+	}
+	if o.leading < 0 {
+		o.leading = 0
+	}
+	if o.trailing < 0 {
+		o.trailing = 0
+	}
+	return o
+}
+
+// Marquee returns frames that scroll text from right to left across a row.
+// Characters that can't be shown are left blank. If row is not on the board,
+// nil is returned.
+func Marquee(text string, row int, opts ...MarqueeOption) []Layout {
+	if row < 0 || row >= Rows {
+		return nil
+	}
+
+	var frames []Layout
+	for _, cells := range marqueeWindows(text, newMarqueeOptions(opts, Columns)) {
+		l := NewLayout()
+		l[row] = cells
+		frames = append(frames, l)
+	}
+	return frames
+}
+
+// ScrollRow returns frames that scroll text across row like Marquee does,
+// while the other rows keep the contents of base, i.e. for a ticker below a
+// dashboard. If row is not on the board, nil is returned.
+func ScrollRow(base Layout, row int, text string, opts ...MarqueeOption) []Layout {
+	if row < 0 || row >= Rows {
+		return nil
+	}
+
+	var frames []Layout
+	for _, cells := range marqueeWindows(text, newMarqueeOptions(opts, Columns)) {
+		l := base
+		l[row] = cells
+		frames = append(frames, l)
+	}
+	return frames
+}
+
+// marqueeWindows returns the successive row contents of a scrolling text.
+func marqueeWindows(text string, o *marqueeOptions) [][Columns]int {
+	strip := make([]int, o.leading, o.leading+len(text)+o.trailing)
+	for _, r := range text {
+		code, ok := EncodeRune(r)
+		if !ok {
+			code = CodeBlank
+		}
+		strip = append(strip, code)
+	}
+	strip = append(strip, make([]int, o.trailing)...)
+	for len(strip) < Columns {
+		strip = append(strip, CodeBlank)
+	}
+
+	last := len(strip) - Columns
+	var windows [][Columns]int
+	for offset := 0; ; offset += o.step {
+		if offset > last {
+			offset = last
+		}
+		var w [Columns]int
+		copy(w[:], strip[offset:])
+		windows = append(windows, w)
+		if offset == last {
+			break
+		}
+	}
+	return windows
+}
+
+// ScrollVertical returns frames that scroll text upwards through the board,
+// one row per frame, i.e. for an announcement longer than the board. The text
+// is word wrapped and centered like Compose does, words longer than a row are
+// split over multiple rows and characters that can't be shown are left blank.
+func ScrollVertical(text string, opts ...MarqueeOption) []Layout {
+	o := newMarqueeOptions(opts, Rows)
+
+	strip := make([][Columns]int, o.leading)
+	for _, line := range splitLines(strings.ReplaceAll(text, "\r\n", "\n"), Columns) {
+		runes := []rune(line)
+		for len(runes) > Columns {
+			strip = append(strip, centeredRow(runes[:Columns]))
+			runes = runes[Columns:]
+		}
+		strip = append(strip, centeredRow(runes))
+	}
+	strip = append(strip, make([][Columns]int, o.trailing)...)
+	for len(strip) < Rows {
+		strip = append(strip, [Columns]int{})
+	}
+
+	last := len(strip) - Rows
+	var frames []Layout
+	for offset := 0; ; offset += o.step {
+		if offset > last {
+			offset = last
+		}
+		var l Layout
+		copy(l[:], strip[offset:])
+		frames = append(frames, l)
+		if offset == last {
+			break
+		}
+	}
+	return frames
+}
+
+// centeredRow returns the codes of a row with runes centered, runes that can't
+// be shown are left blank.
+func centeredRow(runes []rune) [Columns]int {
+	var row [Columns]int
+	y := alignColumn(string(runes), AlignCenter)
+	for i, r := range runes {
+		if code, ok := EncodeRune(r); ok {
+			row[y+i] = code
+		}
+	}
+	return row
+}
+
+// Flash returns frames that alternate between base and a board filled with
+// color, flashing times times. The sequence starts and ends with base, so
+// times of 0 returns just base.
+func Flash(base Layout, color Color, times int) []Layout {
+	frames := []Layout{base}
+	fill := solid(color.Code())
+	for i := 0; i < times; i++ {
+		frames = append(frames, fill, base)
+	}
+	return frames
+}
+
+// finalFrameTimeout bounds sending the final frame, which can't use the
+// context of the animation once that is done. It starts after the wait for
+// the rate limiter.
+const finalFrameTimeout = DefaultTimeout
+
+// PlayOption configures PlayFrames, PlayPages and Animator.Start.
+type PlayOption func(*playOptions)
+
+type playOptions struct {
+	maxFrames   int
+	maxDuration time.Duration
+	truncate    bool
+	final       *Layout
+}
+
+func newPlayOptions(opts []PlayOption) *playOptions {
+	o := &playOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithMaxFrames limits how many frames are played. Longer animations return
+// ErrAnimationTooLong before anything is sent, unless WithTruncate is used.
+func WithMaxFrames(n int) PlayOption {
+	return func(o *playOptions) {
+		o.maxFrames = n
+	}
+}
+
+// WithMaxDuration limits how long an animation plays, i.e. for a ticker that
+// must not run for more than a few minutes. Longer animations return
+// ErrAnimationTooLong before anything is sent, unless WithTruncate is used.
+func WithMaxDuration(d time.Duration) PlayOption {
+	return func(o *playOptions) {
+		o.maxDuration = d
+	}
+}
+
+// WithTruncate plays as many frames as the limits allow instead of returning
+// ErrAnimationTooLong.
+func WithTruncate() PlayOption {
+	return func(o *playOptions) {
+		o.truncate = true
+	}
+}
+
+// WithFinalFrame sends l when the animation is cancelled, so that the board
+// isn't left on an arbitrary frame, i.e. a blank layout when a program shuts
+// down. It is sent with a new context, since the animation's is already done.
+func WithFinalFrame(l Layout) PlayOption {
+	return func(o *playOptions) {
+		o.final = &l
+	}
+}
+
+// limit returns how many of n frames may be played. If waitLast is set, the
+// last frame is shown for interval as well.
+func (o *playOptions) limit(n int, interval time.Duration, waitLast bool) (int, error) {
+	allowed := n
+	if o.maxFrames > 0 && allowed > o.maxFrames {
+		if !o.truncate {
+			return 0, fmt.Errorf("%d frames, the maximum is %d: %w", n, o.maxFrames, ErrAnimationTooLong)
+		}
+		allowed = o.maxFrames
+	}
+	if o.maxDuration > 0 && interval > 0 {
+		fit := int(o.maxDuration / interval)
+		if !waitLast {
+			fit++
+		}
+		if allowed > fit {
+			if !o.truncate {
+				return 0, fmt.Errorf("%d frames at %v take longer than %v: %w", n, interval, o.maxDuration, ErrAnimationTooLong)
+			}
+			allowed = fit
+		}
+	}
+	return allowed, nil
+}
+
+// cancelled sends the final frame, if there is one, after the animation's
+// context is done and returns err, which may be nil, combined with the error
+// of the final frame.
+func (c *RWClient) cancelled(o *playOptions, err error) error {
+	ferr := c.sendFinal(o)
+	switch {
+	case ferr == nil:
+		return err
+	case err == nil:
+		return ferr
+	}
+	return fmt.Errorf("%v: %w", ferr, err)
+}
+
+// sendFinal sends the final frame, if there is one. The rate limiter token is
+// reserved up front and waited for on the client's clock, so that the
+// timeout only covers the request itself, a send right before the
+// cancellation would otherwise use up most of it.
+func (c *RWClient) sendFinal(o *playOptions) error {
+	if o.final == nil {
+		return nil
+	}
+	if c.limiter != nil {
+		if d := c.limiter.Reserve().Delay(); d > 0 {
+			<-c.clock.After(d)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), finalFrameTimeout)
+	defer cancel()
+	if _, err := c.SendMessage(withoutRateLimit(ctx), *o.final); err != nil {
+		return fmt.Errorf("failed to send final frame: %w", err)
+	}
+	return nil
+}
+
+// PlayFrames sends each frame to the board, waiting interval between frames.
+// It returns early if a send fails or the context is done.
+func (c *RWClient) PlayFrames(ctx context.Context, frames []Layout, interval time.Duration, opts ...PlayOption) error {
+	o := newPlayOptions(opts)
+	n, err := o.limit(len(frames), interval, false)
+	if err != nil {
+		return err
+	}
+	for i, frame := range frames[:n] {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return c.cancelled(o, ctx.Err())
+			case <-c.clock.After(interval):
+			}
+		}
+
+		if _, err := c.SendMessage(ctx, frame); err != nil {
+			err = fmt.Errorf("frame %d: %w", i, err)
+			if ctx.Err() != nil {
+				return c.cancelled(o, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// PlayPages shows each page for interval, including the last one, so that
+// calling it in a loop cycles through the pages at an even pace. It returns
+// early if a send fails or the context is done.
+func (c *RWClient) PlayPages(ctx context.Context, pages []Layout, interval time.Duration, opts ...PlayOption) error {
+	o := newPlayOptions(opts)
+	n, err := o.limit(len(pages), interval, true)
+	if err != nil {
+		return err
+	}
+	for i, page := range pages[:n] {
+		if _, err := c.SendMessage(ctx, page); err != nil {
+			err = fmt.Errorf("page %d: %w", i, err)
+			if ctx.Err() != nil {
+				return c.cancelled(o, err)
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return c.cancelled(o, ctx.Err())
+		case <-c.clock.After(interval):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMarquee(t *testing.T) {
+	t.Parallel()
+
+	frames := Marquee("hi", 2)
+	// 22 leading + 2 + 22 trailing cells, one frame per column offset.
+	if want := Columns + 2 + 1; len(frames) != want {
+		t.Fatalf("wrong number of frames, want: %d, got: %d", want, len(frames))
+	}
+	if got := rowText(frames[0], 2); got != strings.Repeat(" ", Columns) {
+		t.Errorf("first frame should be blank, got: %q", got)
+	}
+	if got := rowText(frames[1], 2); got != strings.Repeat(" ", Columns-1)+"H" {
+		t.Errorf("wrong second frame: %q", got)
+	}
+	if got := rowText(frames[Columns], 2); got != "HI"+strings.Repeat(" ", Columns-2) {
+		t.Errorf("wrong frame %d: %q", Columns, got)
+	}
+	for i, f := range frames {
+		for row := 0; row < Rows; row++ {
+			if row != 2 && rowText(f, row) != strings.Repeat(" ", Columns) {
+				t.Errorf("frame %d: row %d is not blank", i, row)
+			}
+		}
+	}
+
+	frames = Marquee("hello", 0, WithPadding(0, 0), WithStep(5))
+	if len(frames) != 1 || rowText(frames[0], 0) != "HELLO"+strings.Repeat(" ", Columns-5) {
+		t.Errorf("short text without padding should be a single frame, got: %d", len(frames))
+	}
+
+	frames = Marquee(strings.Repeat("X", 30), 0, WithPadding(0, 0), WithStep(5))
+	if len(frames) != 3 {
+		t.Errorf("wrong number of frames, want: 3, got %d", len(frames))
+	}
+
+	if frames := Marquee("hi", Rows); frames != nil {
+		t.Errorf("expected nil for invalid row")
+	}
+}
+
+func TestScrollVertical(t *testing.T) {
+	t.Parallel()
+
+	blank := strings.Repeat(" ", Columns)
+	center := func(s string) string {
+		left := (Columns - len(s)) / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", Columns-left-len(s))
+	}
+
+	// A board of padding on either side of the 3 rows of text.
+	frames := ScrollVertical("one\ntwo\nthree")
+	if want := Rows + 3 + 1; len(frames) != want {
+		t.Fatalf("wrong number of frames, want: %d, got: %d", want, len(frames))
+	}
+	if frames[0] != NewLayout() || frames[len(frames)-1] != NewLayout() {
+		t.Errorf("first and last frame should be blank")
+	}
+	if got := rowText(frames[1], 5); got != center("ONE") {
+		t.Errorf("second frame should show the first line at the bottom, got: %q", got)
+	}
+	for row, want := range []string{"ONE", "TWO", "THREE"} {
+		if got := rowText(frames[Rows], row); got != center(want) {
+			t.Errorf("frame %d, row %d: want: %q, got: %q", Rows, row, center(want), got)
+		}
+	}
+
+	// Without padding text that fits is a single frame, long words are split
+	// and unsupported characters are blank.
+	frames = ScrollVertical(strings.Repeat("X", Columns+2)+" A_B", WithPadding(0, 0))
+	if len(frames) != 1 {
+		t.Fatalf("want a single frame, got %d", len(frames))
+	}
+	for row, want := range []string{strings.Repeat("X", Columns), center("XX"), center("A B"), blank} {
+		if got := rowText(frames[0], row); got != want {
+			t.Errorf("row %d: want: %q, got: %q", row, want, got)
+		}
+	}
+
+	// The last frame is always included.
+	if frames := ScrollVertical("one", WithStep(4)); len(frames) != 3 {
+		t.Errorf("wrong number of frames with a step of 4: %d", len(frames))
+	}
+}
+
+func TestScrollRow(t *testing.T) {
+	t.Parallel()
+
+	b := NewLayoutBuilder()
+	if err := b.SetRow(0, "dashboard", AlignCenter); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetRow(5, "replaced", AlignLeft); err != nil {
+		t.Fatal(err)
+	}
+	base, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames := ScrollRow(base, 5, "news", WithPadding(Columns, 0))
+	if want := 5; len(frames) != want {
+		t.Fatalf("wrong number of frames, want: %d, got: %d", want, len(frames))
+	}
+	if got := rowText(frames[0], 5); got != strings.Repeat(" ", Columns) {
+		t.Errorf("first frame should be blank, got: %q", got)
+	}
+	if got := rowText(frames[4], 5); got != strings.Repeat(" ", Columns-4)+"NEWS" {
+		t.Errorf("wrong last frame: %q", got)
+	}
+	for i, f := range frames {
+		for row := 0; row < Rows-1; row++ {
+			if f[row] != base[row] {
+				t.Errorf("frame %d: row %d changed", i, row)
+			}
+		}
+	}
+
+	if frames := ScrollRow(base, -1, "news"); frames != nil {
+		t.Errorf("expected nil for invalid row")
+	}
+}
+
+func TestPlayFrames(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	frames := Marquee("hi", 0, WithPadding(0, 0))
+	frames = append(frames, frames...)
+	if err := c.PlayFrames(context.Background(), frames, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); int(got) != len(frames) {
+		t.Errorf("wrong number of sends, want: %d, got: %d", len(frames), got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.PlayFrames(ctx, frames, time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestFlash(t *testing.T) {
+	t.Parallel()
+
+	base, err := CenterLine("alert", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frames := Flash(base, ColorRed, 0); len(frames) != 1 || frames[0] != base {
+		t.Errorf("times=0 should return the base layout only, got %d frames", len(frames))
+	}
+
+	frames := Flash(base, ColorRed, 2)
+	if len(frames) != 5 {
+		t.Fatalf("wrong number of frames, want: 5, got: %d", len(frames))
+	}
+	for i, f := range frames {
+		if i%2 == 0 {
+			if f != base {
+				t.Errorf("frame %d should be the base layout", i)
+			}
+			continue
+		}
+		if f != solid(int(ColorRed)) {
+			t.Errorf("frame %d should be solid red", i)
+		}
+	}
+}
+
+func TestPlayPages(t *testing.T) {
+	t.Parallel()
+
+	pages, err := Paginate(strings.Repeat("PAGE ", 40))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{}
+	h := &boardHandler{t: t}
+	c := newRWTestClient(t, h.ServeHTTP, WithClock(clock))
+	if err := c.PlayPages(context.Background(), pages, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := h.Posted(); len(got) != len(pages) || got[len(got)-1] != pages[len(pages)-1] {
+		t.Errorf("expected every page to be sent, got %d", len(got))
+	}
+	if d := clock.Delays(); len(d) != len(pages) {
+		t.Errorf("expected every page to be shown for the interval, got: %v", d)
+	}
+}
+
+func TestPlayLimits(t *testing.T) {
+	t.Parallel()
+
+	frames := make([]Layout, 10)
+	for i := range frames {
+		frames[i] = solid(ColorRed.Code() + i%2)
+	}
+
+	cases := []struct {
+		name     string
+		pages    bool
+		opts     []PlayOption
+		wantSent int
+		wantErr  error
+	}{
+		{name: "no_limits", wantSent: 10},
+		{name: "within_limits", opts: []PlayOption{WithMaxFrames(10), WithMaxDuration(9 * time.Second)}, wantSent: 10},
+		{name: "too_many_frames", opts: []PlayOption{WithMaxFrames(5)}, wantErr: ErrAnimationTooLong},
+		{name: "too_long", opts: []PlayOption{WithMaxDuration(5 * time.Second)}, wantErr: ErrAnimationTooLong},
+		{name: "truncate_frames", opts: []PlayOption{WithMaxFrames(5), WithTruncate()}, wantSent: 5},
+		// Six frames play for five intervals.
+		{name: "truncate_duration", opts: []PlayOption{WithMaxDuration(5 * time.Second), WithTruncate()}, wantSent: 6},
+		{name: "pages_truncate_duration", pages: true, opts: []PlayOption{WithMaxDuration(5 * time.Second), WithTruncate()}, wantSent: 5},
+		{name: "pages_too_long", pages: true, opts: []PlayOption{WithMaxDuration(9 * time.Second)}, wantErr: ErrAnimationTooLong},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &boardHandler{t: t}
+			c := newRWTestClient(t, h.ServeHTTP, WithClock(&fakeClock{}))
+
+			var err error
+			if tc.pages {
+				err = c.PlayPages(context.Background(), frames, time.Second, tc.opts...)
+			} else {
+				err = c.PlayFrames(context.Background(), frames, time.Second, tc.opts...)
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+			if got := len(h.Posted()); got != tc.wantSent {
+				t.Errorf("wrong number of sends, want: %d, got: %d", tc.wantSent, got)
+			}
+		})
+	}
+}
+
+func TestPlayFinalFrame(t *testing.T) {
+	t.Parallel()
+
+	frames := []Layout{solid(ColorRed.Code()), solid(ColorBlue.Code())}
+	final := solid(CodeBlank)
+
+	for _, pages := range []bool{false, true} {
+		h := &boardHandler{t: t}
+		c := newRWTestClient(t, h.ServeHTTP)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			for len(h.Posted()) == 0 {
+				time.Sleep(5 * time.Millisecond)
+			}
+			cancel()
+		}()
+		var err error
+		if pages {
+			err = c.PlayPages(ctx, frames, time.Hour, WithFinalFrame(final))
+		} else {
+			err = c.PlayFrames(ctx, frames, time.Hour, WithFinalFrame(final))
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("pages=%t: wrong error: %v", pages, err)
+		}
+		posted := h.Posted()
+		if len(posted) != 2 || posted[0] != frames[0] || posted[1] != final {
+			t.Errorf("pages=%t: expected the first and the final frame, got %d messages", pages, len(posted))
+		}
+	}
+
+	// With the default rate limit the final frame waits for its token on the
+	// client's clock, not within the timeout of the request.
+	h := &boardHandler{t: t}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := &fakeClock{}
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		cancel()
+	}, WithClock(clock), WithRateLimit(DefaultRateLimit, 1))
+	start := time.Now()
+	if err := c.PlayFrames(ctx, frames, time.Minute, WithFinalFrame(final)); !errors.Is(err, context.Canceled) {
+		t.Errorf("rate limited: wrong error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("rate limited: final frame took %v", elapsed)
+	}
+	if posted := h.Posted(); len(posted) != 2 || posted[1] != final {
+		t.Errorf("rate limited: expected the first and the final frame, got %d messages", len(posted))
+	}
+	waited := false
+	for _, d := range clock.Delays() {
+		if d > 10*time.Second && d <= 15*time.Second {
+			waited = true
+		}
+	}
+	if !waited {
+		t.Errorf("rate limited: final frame didn't wait for the limiter, delays: %v", clock.Delays())
+	}
+
+	// Without cancellation the final frame isn't sent.
+	h = &boardHandler{t: t}
+	c = newRWTestClient(t, h.ServeHTTP, WithClock(&fakeClock{}))
+	if err := c.PlayFrames(context.Background(), frames, time.Second, WithFinalFrame(final)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if posted := h.Posted(); len(posted) != len(frames) {
+		t.Errorf("unexpected final frame, got %d messages", len(posted))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Animator plays frames on a board in a loop until it is stopped, and can be
+// paused in between. It is safe for concurrent use.
+type Animator struct {
+	client *RWClient
+
+	mu       sync.Mutex
+	running  bool
+	paused   bool
+	stopping bool
+	// wake is signalled when the animator is paused or resumed.
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// NewAnimator creates an Animator that sends through c.
+func NewAnimator(c *RWClient) *Animator {
+	return &Animator{client: c}
+}
+
+// Start plays frames in a loop in the background, waiting interval between
+// frames, until Stop is called or ctx is done. A failed send ends the loop.
+// Of opts, only WithFinalFrame is used, it is sent when the loop is stopped or
+// ctx is done.
+func (a *Animator) Start(ctx context.Context, frames []Layout, interval time.Duration, opts ...PlayOption) error {
+	if len(frames) == 0 {
+		return errors.New("no frames to play")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.running {
+		return errors.New("animator is already running")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	a.running = true
+	a.paused = false
+	a.stopping = false
+	a.wake = make(chan struct{}, 1)
+	a.cancel = cancel
+	a.done = make(chan struct{})
+	a.err = nil
+
+	go a.run(ctx, frames, interval, newPlayOptions(opts), a.wake, a.done)
+	return nil
+}
+
+func (a *Animator) run(ctx context.Context, frames []Layout, interval time.Duration, o *playOptions, wake <-chan struct{}, done chan<- struct{}) {
+	err := a.loop(ctx, frames, interval, wake)
+
+	a.mu.Lock()
+	if a.stopping && errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	a.mu.Unlock()
+	if ctx.Err() != nil {
+		err = a.client.cancelled(o, err)
+	}
+
+	a.mu.Lock()
+	a.err = err
+	a.running = false
+	a.mu.Unlock()
+	close(done)
+}
+
+func (a *Animator) loop(ctx context.Context, frames []Layout, interval time.Duration, wake <-chan struct{}) error {
+	for i := 0; ; i = (i + 1) % len(frames) {
+		if _, err := a.client.SendMessage(ctx, frames[i]); err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.client.clock.After(interval):
+		case <-wake:
+		}
+
+		// Once resumed, the next frame is sent right away.
+		for a.isPaused() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-wake:
+			}
+		}
+	}
+}
+
+func (a *Animator) isPaused() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.paused
+}
+
+// setPaused changes the paused state and wakes up the loop if it changed.
+func (a *Animator) setPaused(paused bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.running || a.paused == paused {
+		return
+	}
+	a.paused = paused
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Pause holds the current frame on the board until Resume is called.
+func (a *Animator) Pause() {
+	a.setPaused(true)
+}
+
+// Resume continues a paused animation, the next frame is sent immediately.
+func (a *Animator) Resume() {
+	a.setPaused(false)
+}
+
+// Stop ends the animation and waits for it to finish. It returns the error
+// that ended the animation early, if any.
+func (a *Animator) Stop() error {
+	a.mu.Lock()
+	if a.done == nil {
+		a.mu.Unlock()
+		return nil
+	}
+	a.stopping = true
+	a.cancel()
+	done := a.done
+	a.mu.Unlock()
+
+	<-done
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
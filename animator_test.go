@@ -0,0 +1,124 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// waitPosted waits until h has received n messages.
+func waitPosted(t *testing.T, h *boardHandler, n int) []Layout {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		posted := h.Posted()
+		if len(posted) >= n {
+			return posted
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d messages, got %d", n, len(posted))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAnimatorPauseResume(t *testing.T) {
+	t.Parallel()
+
+	frames := make([]Layout, 3)
+	for i := range frames {
+		frames[i] = NewLayout()
+		frames[i][0][i] = int(ColorRed)
+	}
+
+	h := &boardHandler{t: t}
+	a := NewAnimator(newRWTestClient(t, h.ServeHTTP))
+
+	// With a long interval, frames only advance because of Resume.
+	if err := a.Start(context.Background(), frames, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Start(context.Background(), frames, time.Hour); err == nil {
+		t.Errorf("expected an error starting a running animator")
+	}
+	waitPosted(t, h, 1)
+
+	a.Pause()
+	time.Sleep(20 * time.Millisecond)
+	if n := len(h.Posted()); n != 1 {
+		t.Errorf("expected no frames while paused, got %d", n)
+	}
+
+	a.Resume()
+	posted := waitPosted(t, h, 2)
+	if posted[1] != frames[1] {
+		t.Errorf("expected the next frame on resume, got: %v", posted[1])
+	}
+
+	if err := a.Stop(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := a.Start(context.Background(), frames, time.Millisecond); err != nil {
+		t.Fatalf("expected a stopped animator to restart: %v", err)
+	}
+	posted = waitPosted(t, h, 2+len(frames)+1)
+	if posted[2+len(frames)] != frames[0] {
+		t.Errorf("expected the frames to loop")
+	}
+	if err := a.Stop(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAnimatorSendError(t *testing.T) {
+	t.Parallel()
+
+	a := NewAnimator(newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	if err := a.Start(context.Background(), []Layout{NewLayout()}, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := a.Stop(); err == nil {
+		t.Errorf("expected the send error")
+	}
+}
+
+func TestAnimatorFinalFrame(t *testing.T) {
+	t.Parallel()
+
+	frames := Marquee("tick", 0)
+	final := solid(CodeBlank)
+
+	h := &boardHandler{t: t}
+	a := NewAnimator(newRWTestClient(t, h.ServeHTTP))
+	if err := a.Start(context.Background(), frames, time.Hour, WithFinalFrame(final)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitPosted(t, h, 1)
+	if err := a.Stop(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	posted := h.Posted()
+	if len(posted) != 2 || posted[1] != final {
+		t.Errorf("expected the final frame after stopping, got %d messages", len(posted))
+	}
+}
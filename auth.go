@@ -0,0 +1,48 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"net/http"
+)
+
+// keyTransport adds the API key headers of a client to every request, so that
+// no endpoint can forget them. Headers that are already set on a request are
+// left alone.
+type keyTransport struct {
+	next http.RoundTripper
+	keys map[string]string
+}
+
+func newKeyTransport(next http.RoundTripper, keys map[string]string) *keyTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &keyTransport{
+		next: next,
+		keys: keys,
+	}
+}
+
+func (t *keyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request, so the headers are set on a copy.
+	clone := req.Clone(req.Context())
+	for h, v := range t.keys {
+		if v != "" && clone.Header.Get(h) == "" {
+			clone.Header.Set(h, v)
+		}
+	}
+	return t.next.RoundTrip(clone)
+}
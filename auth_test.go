@@ -0,0 +1,120 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestKeyTransport(t *testing.T) {
+	t.Parallel()
+
+	headers := make(chan http.Header, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cases := []struct {
+		name string
+		send func(ctx context.Context) error
+		want map[string]string
+	}{
+		{
+			name: "platform",
+			send: func(ctx context.Context) error {
+				_, err := New("key", "secret", WithBaseURL(srv.URL)).Viewer(ctx)
+				return err
+			},
+			want: map[string]string{APIKeyHeader: "key", APIKeySecret: "secret"},
+		},
+		{
+			name: "rw",
+			send: func(ctx context.Context) error {
+				_, err := NewRWClient("rw-key", WithBaseURL(srv.URL)).ReadMessage(ctx)
+				return err
+			},
+			want: map[string]string{RWAPIKeyHeader: "rw-key"},
+		},
+		{
+			name: "local",
+			send: func(ctx context.Context) error {
+				return NewLocalClient(srv.URL, "local-key").WriteMessage(ctx, NewLayout())
+			},
+			want: map[string]string{LocalAPIKeyHeader: "local-key"},
+		},
+		{
+			name: "local_enablement",
+			send: func(ctx context.Context) error {
+				_, err := NewLocalClient(srv.URL, "").EnableLocalAPI(ctx, "token")
+				return err
+			},
+			// An empty key isn't sent.
+			want: map[string]string{LocalAPIKeyHeader: "", LocalAPIEnablementKeyHeader: "token"},
+		},
+	}
+
+	for _, tc := range cases {
+		// The cases share the server, so they don't run in parallel.
+		tc.send(context.Background())
+		got := <-headers
+		for h, want := range tc.want {
+			if v := got.Get(h); v != want {
+				t.Errorf("%s: header %s, want: %q, got: %q", tc.name, h, want, v)
+			}
+		}
+	}
+}
+
+func TestKeyTransportKeepsHeaders(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	rt := newKeyTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.Header.Get(RWAPIKeyHeader)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}), map[string]string{RWAPIKeyHeader: "default"})
+
+	req := httptest.NewRequest(http.MethodGet, "http://board/", nil)
+	req.Header.Set(RWAPIKeyHeader, "override")
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "override" {
+		t.Errorf("wrong key, want: %q, got: %q", "override", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://board/", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "default" {
+		t.Errorf("wrong key, want: %q, got: %q", "default", got)
+	}
+	if v := req.Header.Get(RWAPIKeyHeader); v != "" {
+		t.Errorf("request was modified, got key: %q", v)
+	}
+}
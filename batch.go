@@ -0,0 +1,138 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultBatchDelay is how long a BatchedClient waits for further updates
+// before sending the latest layout.
+const DefaultBatchDelay = time.Second
+
+// BatchOption configures a BatchedClient.
+type BatchOption func(*BatchedClient)
+
+// WithBatchDelay sets how long the client waits after the last Enqueue before
+// sending, the default is DefaultBatchDelay.
+func WithBatchDelay(d time.Duration) BatchOption {
+	return func(b *BatchedClient) {
+		b.delay = d
+	}
+}
+
+// WithFlushErrorHandler sets a function that is called with the error of a
+// send that was triggered by the timer. By default these errors are dropped.
+func WithFlushErrorHandler(fn func(error)) BatchOption {
+	return func(b *BatchedClient) {
+		b.onError = fn
+	}
+}
+
+// BatchedClient coalesces rapid updates to a board, only the most recent
+// layout enqueued within the delay is sent. It is safe for concurrent use.
+type BatchedClient struct {
+	client  *RWClient
+	delay   time.Duration
+	onError func(error)
+
+	// sendMu serializes sends, so an older layout can't overtake a newer one.
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending *Layout
+	// stop is closed to cancel the wait for the delay of the last Enqueue.
+	stop chan struct{}
+}
+
+// NewBatchedClient creates a BatchedClient that sends through c.
+func NewBatchedClient(c *RWClient, opts ...BatchOption) *BatchedClient {
+	b := &BatchedClient{
+		client: c,
+		delay:  DefaultBatchDelay,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Enqueue schedules l to be sent once no further layout has been enqueued for
+// the delay. A layout that is still pending is replaced and never sent.
+func (b *BatchedClient) Enqueue(l Layout) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = &l
+	if b.stop != nil {
+		close(b.stop)
+	}
+	b.stop = make(chan struct{})
+	go b.flushAfter(b.stop)
+}
+
+// flushAfter sends the pending layout after the delay, unless stop is closed
+// first. The delay uses the clock of the client.
+func (b *BatchedClient) flushAfter(stop chan struct{}) {
+	select {
+	case <-stop:
+		return
+	case <-b.client.clock.After(b.delay):
+	}
+
+	b.mu.Lock()
+	if b.stop != stop {
+		// Replaced by a later Enqueue or a Flush while the delay ran out.
+		b.mu.Unlock()
+		return
+	}
+	b.stop = nil
+	b.mu.Unlock()
+
+	if err := b.flush(context.Background()); err != nil && b.onError != nil {
+		b.onError(err)
+	}
+}
+
+// Flush sends the pending layout immediately, if there is one, i.e. on
+// shutdown.
+func (b *BatchedClient) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	if b.stop != nil {
+		close(b.stop)
+		b.stop = nil
+	}
+	b.mu.Unlock()
+
+	return b.flush(ctx)
+}
+
+func (b *BatchedClient) flush(ctx context.Context) error {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	l := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if l == nil {
+		return nil
+	}
+	_, err := b.client.SendMessage(ctx, *l)
+	return err
+}
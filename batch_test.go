@@ -0,0 +1,214 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// layoutRecorder returns a handler that records every layout posted to it.
+func layoutRecorder(t *testing.T, mu *sync.Mutex, got *[]Layout) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var l Layout
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		mu.Lock()
+		*got = append(*got, l)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}
+}
+
+func TestBatchedClientCoalesces(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var got []Layout
+	errs := make(chan error, 1)
+	c := newRWTestClient(t, layoutRecorder(t, &mu, &got))
+	b := NewBatchedClient(c, WithBatchDelay(50*time.Millisecond), WithFlushErrorHandler(func(err error) {
+		errs <- err
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l := NewLayout()
+			l[0][i] = int(ColorRed)
+			b.Enqueue(l)
+		}(i)
+	}
+	wg.Wait()
+	last := NewLayout()
+	last.Print(0, 0, "LATEST")
+	b.Enqueue(last)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected flush error: %v", err)
+	default:
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 {
+		t.Fatalf("expected a single send, got %d", len(got))
+	}
+	if got[0] != last {
+		t.Errorf("expected the latest layout to be sent, got: %v", got[0])
+	}
+}
+
+func TestBatchedClientFlush(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var got []Layout
+	c := newRWTestClient(t, layoutRecorder(t, &mu, &got))
+	b := NewBatchedClient(c, WithBatchDelay(time.Hour))
+
+	ctx := context.Background()
+	if err := b.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error flushing nothing: %v", err)
+	}
+
+	want := NewLayout()
+	want.Print(0, 0, "SHUTDOWN")
+	b.Enqueue(NewLayout())
+	b.Enqueue(want)
+	if err := b.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("expected only the latest layout to be sent once, got: %v", got)
+	}
+}
+
+// manualClock is a Clock whose After channels only fire when the test calls
+// fire.
+type manualClock struct {
+	mu     sync.Mutex
+	waits  []chan time.Time
+	delays []time.Duration
+}
+
+func (c *manualClock) Now() time.Time        { return time.Time{} }
+func (c *manualClock) Sleep(d time.Duration) { <-c.After(d) }
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waits = append(c.waits, ch)
+	c.delays = append(c.delays, d)
+	return ch
+}
+
+// waitFor blocks until After was called n times and returns the delays.
+func (c *manualClock) waitFor(t *testing.T, n int) []time.Duration {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		if len(c.waits) >= n {
+			delays := append([]time.Duration(nil), c.delays...)
+			c.mu.Unlock()
+			return delays
+		}
+		c.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatalf("After wasn't called %d times", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (c *manualClock) fire(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits[i] <- time.Time{}
+}
+
+func TestBatchedClientClock(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var got []Layout
+	clock := &manualClock{}
+	c := newRWTestClient(t, layoutRecorder(t, &mu, &got), WithClock(clock))
+	b := NewBatchedClient(c, WithBatchDelay(time.Hour))
+
+	first, last := NewLayout(), NewLayout()
+	first.Print(0, 0, "FIRST")
+	last.Print(0, 0, "LAST")
+	b.Enqueue(first)
+	b.Enqueue(last)
+
+	delays := clock.waitFor(t, 2)
+	for i, d := range delays {
+		if d != time.Hour {
+			t.Errorf("wait %d: want: %v, got: %v", i, time.Hour, d)
+		}
+	}
+
+	// The wait of the replaced layout doesn't send anything.
+	clock.fire(0)
+	clock.fire(1)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Give a wrongly triggered send from the first wait time to arrive.
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 || got[0] != last {
+		t.Errorf("expected only the latest layout to be sent once, got: %v", got)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"image"
+)
+
+var ErrInvalidImage = errors.New("invalid image")
+
+// FromBitmap renders a 22x6 pixel image onto a layout. Dark pixels become the
+// on color and light pixels the off color. Fully transparent pixels are left
+// blank.
+func FromBitmap(img image.Image, on Color, off Color) (Layout, error) {
+	l := NewLayout()
+	for _, c := range []Color{on, off} {
+		if c != Black && !c.valid() {
+			return l, fmt.Errorf("%v: %w", c, ErrInvalidColor)
+		}
+	}
+
+	b := img.Bounds()
+	if b.Dx() != Columns || b.Dy() != Rows {
+		return l, fmt.Errorf("image is %dx%d, want %dx%d: %w", b.Dx(), b.Dy(), Columns, Rows, ErrInvalidImage)
+	}
+
+	for x := 0; x < Rows; x++ {
+		for y := 0; y < Columns; y++ {
+			r, g, bl, a := img.At(b.Min.X+y, b.Min.Y+x).RGBA()
+			if a == 0 {
+				l[x][y] = CodeBlank
+				continue
+			}
+			// Undo the alpha premultiplication so that translucent pixels are
+			// judged by their color.
+			r, g, bl = r*0xffff/a, g*0xffff/a, bl*0xffff/a
+			lum := (299*r + 587*g + 114*bl) / 1000
+			if lum < 0x8000 {
+				l[x][y] = on.Code()
+			} else {
+				l[x][y] = off.Code()
+			}
+		}
+	}
+	return l, nil
+}
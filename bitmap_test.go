@@ -0,0 +1,58 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFromBitmap(t *testing.T) {
+	t.Parallel()
+
+	// Use a non-zero origin to make sure bounds are respected.
+	img := image.NewNRGBA(image.Rect(10, 10, 10+Columns, 10+Rows))
+	for x := 10; x < 10+Columns; x++ {
+		for y := 10; y < 10+Rows; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	img.Set(10, 10, color.Black)
+	img.Set(11, 10, color.NRGBA{R: 20, G: 20, B: 20, A: 128})
+	img.Set(12, 10, color.NRGBA{A: 0})
+
+	l, err := FromBitmap(img, ColorRed, ColorWhite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l[0][0] != int(ColorRed) || l[0][1] != int(ColorRed) {
+		t.Errorf("dark pixels not on: %v", l[0][:2])
+	}
+	if l[0][2] != CodeBlank {
+		t.Errorf("transparent pixel not blank: %d", l[0][2])
+	}
+	if l[5][21] != int(ColorWhite) {
+		t.Errorf("light pixel not off: %d", l[5][21])
+	}
+
+	if _, err := FromBitmap(image.NewGray(image.Rect(0, 0, 6, 22)), ColorRed, ColorWhite); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if _, err := FromBitmap(img, Color(5), ColorWhite); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
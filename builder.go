@@ -0,0 +1,101 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// LayoutBuilder assembles a layout row by row. The zero value is an empty
+// board, ready to use.
+type LayoutBuilder struct {
+	layout Layout
+}
+
+// NewLayoutBuilder returns a builder for an empty board.
+func NewLayoutBuilder() *LayoutBuilder {
+	return &LayoutBuilder{}
+}
+
+// SetRow replaces the contents of a row with text, aligned within the row and
+// padded with blanks.
+func (b *LayoutBuilder) SetRow(row int, text string, align Align) error {
+	if row < 0 || row >= Rows {
+		return fmt.Errorf("row %d is outside of the range 0-%d: %w", row, Rows-1, ErrInvalidCoordinate)
+	}
+
+	text = strings.ToUpper(text)
+	if err := ValidText(text, false); err != nil {
+		return fmt.Errorf("row %d: %w", row, err)
+	}
+	if n := utf8.RuneCountInString(text); n > Columns {
+		return fmt.Errorf("row %d: text is %d characters, the maximum is %d: %w", row, n, Columns, ErrMessageTruncated)
+	}
+
+	b.layout[row] = [Columns]int{}
+	return b.layout.Print(row, alignColumn(text, align), text)
+}
+
+// PlaceAt writes text starting at row and col, leaving the rest of the row as
+// it is. Text that doesn't fit in the rest of the row returns
+// ErrMessageTruncated.
+func (b *LayoutBuilder) PlaceAt(row, col int, text string) error {
+	if err := b.layout.ValidCoordinate(row, col); err != nil {
+		return fmt.Errorf("row %d, column %d: %w", row, col, err)
+	}
+
+	text = strings.ToUpper(text)
+	if err := ValidText(text, false); err != nil {
+		return fmt.Errorf("row %d: %w", row, err)
+	}
+	if n := utf8.RuneCountInString(text); col+n > Columns {
+		return fmt.Errorf("row %d: text is %d characters, only %d fit after column %d: %w", row, n, Columns-col, col, ErrMessageTruncated)
+	}
+	return b.layout.Print(row, col, text)
+}
+
+// FillRect sets every cell from row r0, column c0 to row r1, column c1, both
+// inclusive, to code. The corners must be on the board with r0 <= r1 and
+// c0 <= c1.
+func (b *LayoutBuilder) FillRect(r0, c0, r1, c1 int, code int) error {
+	for _, p := range [][2]int{{r0, c0}, {r1, c1}} {
+		if err := b.layout.ValidCoordinate(p[0], p[1]); err != nil {
+			return fmt.Errorf("row %d, column %d: %w", p[0], p[1], err)
+		}
+	}
+	if r0 > r1 || c0 > c1 {
+		return fmt.Errorf("corner %d,%d is after %d,%d: %w", r0, c0, r1, c1, ErrInvalidCoordinate)
+	}
+	if !ValidCode(code) {
+		return fmt.Errorf("code %d: %w", code, ErrInvalidCode)
+	}
+
+	for x := r0; x <= r1; x++ {
+		for y := c0; y <= c1; y++ {
+			b.layout[x][y] = code
+		}
+	}
+	return nil
+}
+
+// Build returns the assembled layout after validating it.
+func (b *LayoutBuilder) Build() (Layout, error) {
+	if err := b.layout.Validate(); err != nil {
+		return NewLayout(), err
+	}
+	return b.layout, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLayoutBuilderSetRow(t *testing.T) {
+	t.Parallel()
+
+	b := NewLayoutBuilder()
+	if err := b.SetRow(0, "weather", AlignCenter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.SetRow(2, "sunny 72°", AlignLeft); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.SetRow(3, "wind 5 mph", AlignRight); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Replacing a row clears what was there before.
+	if err := b.SetRow(5, strings.Repeat("X", Columns), AlignLeft); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.SetRow(5, "bye", AlignLeft); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	l, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[int]string{
+		0: "       WEATHER        ",
+		1: strings.Repeat(" ", Columns),
+		2: "SUNNY 72°             ",
+		3: "            WIND 5 MPH",
+		5: "BYE                   ",
+	}
+	for row, w := range want {
+		if got := rowText(l, row); got != w {
+			t.Errorf("row %d, want: %q, got: %q", row, w, got)
+		}
+	}
+
+	if err := b.SetRow(1, strings.Repeat("X", Columns+1), AlignLeft); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if err := b.SetRow(-1, "X", AlignLeft); !errors.Is(err, ErrInvalidCoordinate) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if err := b.SetRow(1, "tab\there", AlignLeft); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestLayoutBuilderPlaceAt(t *testing.T) {
+	t.Parallel()
+
+	b := NewLayoutBuilder()
+	if err := b.SetRow(0, "weather", AlignLeft); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Placed text keeps the rest of the row.
+	if err := b.PlaceAt(0, Columns-3, "72°"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.PlaceAt(5, 0, "12:30"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	l, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[int]string{
+		0: "WEATHER            72°",
+		5: "12:30                 ",
+	}
+	for row, w := range want {
+		if got := rowText(l, row); got != w {
+			t.Errorf("row %d, want: %q, got: %q", row, w, got)
+		}
+	}
+
+	cases := []struct {
+		name    string
+		row     int
+		col     int
+		text    string
+		wantErr error
+	}{
+		{name: "overflow", row: 1, col: Columns - 2, text: "abc", wantErr: ErrMessageTruncated},
+		{name: "negative_col", row: 1, col: -1, text: "a", wantErr: ErrInvalidCoordinate},
+		{name: "bad_row", row: Rows, col: 0, text: "a", wantErr: ErrInvalidCoordinate},
+		{name: "invalid_char", row: 1, col: 0, text: "a\tb", wantErr: ErrInvalidCharacter},
+	}
+	for _, tc := range cases {
+		if err := b.PlaceAt(tc.row, tc.col, tc.text); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: wrong error, want: %v, got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+	if got := rowText(b.layout, 1); got != strings.Repeat(" ", Columns) {
+		t.Errorf("failed placements changed the row: %q", got)
+	}
+}
+
+func TestLayoutBuilderFillRect(t *testing.T) {
+	t.Parallel()
+
+	b := NewLayoutBuilder()
+	if err := b.FillRect(1, 2, 2, 4, ColorBlue.Code()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A single cell is a valid rectangle.
+	if err := b.FillRect(5, 21, 5, 21, ColorRed.Code()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	l, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x, row := range l {
+		for y, code := range row {
+			want := CodeBlank
+			switch {
+			case x >= 1 && x <= 2 && y >= 2 && y <= 4:
+				want = ColorBlue.Code()
+			case x == 5 && y == 21:
+				want = ColorRed.Code()
+			}
+			if code != want {
+				t.Errorf("row %d, column %d: want: %d, got: %d", x, y, want, code)
+			}
+		}
+	}
+
+	cases := []struct {
+		name           string
+		r0, c0, r1, c1 int
+		code           int
+		wantErr        error
+	}{
+		{name: "out_of_bounds", r0: 0, c0: 0, r1: Rows, c1: 0, code: 1, wantErr: ErrInvalidCoordinate},
+		{name: "negative", r0: -1, c0: 0, r1: 0, c1: 0, code: 1, wantErr: ErrInvalidCoordinate},
+		{name: "rows_reversed", r0: 3, c0: 0, r1: 2, c1: 0, code: 1, wantErr: ErrInvalidCoordinate},
+		{name: "columns_reversed", r0: 0, c0: 5, r1: 0, c1: 4, code: 1, wantErr: ErrInvalidCoordinate},
+		{name: "invalid_code", r0: 0, c0: 0, r1: 0, c1: 0, code: 99, wantErr: ErrInvalidCode},
+	}
+	for _, tc := range cases {
+		if err := b.FillRect(tc.r0, tc.c0, tc.r1, tc.c1, tc.code); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: wrong error, want: %v, got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestCancelledBeforeRequest(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opts := []Option{WithBaseURL(srv.URL), WithRetry(3, time.Millisecond)}
+	rw := NewRWClient("rw-key", opts...)
+	sub := New("key", "secret", opts...)
+	sends := map[string]func() error{
+		"rw_message": func() error { _, err := rw.SendMessage(ctx, NewLayout()); return err },
+		"rw_text":    func() error { _, err := rw.SendText(ctx, "hi"); return err },
+		"rw_read":    func() error { _, err := rw.ReadMessage(ctx); return err },
+		"message":    func() error { _, err := sub.SendMessage(ctx, "sub", NewLayout()); return err },
+		"text":       func() error { _, err := sub.SendText(ctx, "sub", "hi"); return err },
+	}
+	for name, send := range sends {
+		if err := send(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: wrong error, want: %v, got: %v", name, context.Canceled, err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+func TestCancelledDuringRequest(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		io.Copy(io.Discard, r.Body)
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	c := NewRWClient("rw-key", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()),
+		WithRateLimit(rate.Inf, 0), WithRetry(3, time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := c.SendText(ctx, "hi")
+	close(release)
+	srv.Close()
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error, want: %v, got: %v", context.Canceled, err)
+	}
+	// A cancelled attempt isn't retried.
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single attempt, got %d", n)
+	}
+}
+
+func TestCancelledDuringRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetry(5, time.Hour), WithRateLimit(rate.Inf, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.SendText(ctx, "hi")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("wrong error, want: %v, got: %v", context.DeadlineExceeded, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry loop didn't stop when the context was done")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single attempt, got %d", n)
+	}
+}
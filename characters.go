@@ -17,14 +17,21 @@ package vestaboard
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
 // PrintableChars is a string of all of the Vestaboard accepted chrs
 const PrintableChars = " ABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890!@#$() - +&=;: '\"%,.  /? °"
 
+// degreeAliases are accepted as the degree sign: the masculine ordinal and
+// the ring above.
+const degreeAliases = "º˚"
+
 // Color represents constants for supported colors.
 type Color int
 
+// Black is the blank tile, which shows as black on a black board.
 const Black Color = 0
 const (
 	PoppyRed Color = iota + 63
@@ -36,22 +43,148 @@ const (
 	White
 )
 
+// Color tile codes, using the names from the Vestaboard documentation. Note
+// that ColorBlack, code 70, is a black tile, while Black, code 0, is the blank
+// cell. ColorFilled, code 71, is the solid tile in the opposite color of the
+// board, white on a black board and black on a white one.
+const (
+	ColorRed    Color = PoppyRed
+	ColorOrange Color = Orange
+	ColorYellow Color = Yellow
+	ColorGreen  Color = Green
+	ColorBlue   Color = ParisBlue
+	ColorViolet Color = Violet
+	ColorWhite  Color = White
+	ColorBlack  Color = 70
+	ColorFilled Color = 71
+)
+
+const (
+	// CodeBlank is the code of an empty cell.
+	CodeBlank = 0
+	// CodeDegree is the code of the degree sign.
+	CodeDegree = 62
+)
+
+var colorNames = map[string]Color{
+	"blank":     Black,
+	"red":       ColorRed,
+	"poppyred":  ColorRed,
+	"orange":    ColorOrange,
+	"yellow":    ColorYellow,
+	"green":     ColorGreen,
+	"blue":      ColorBlue,
+	"parisblue": ColorBlue,
+	"violet":    ColorViolet,
+	"purple":    ColorViolet,
+	"white":     ColorWhite,
+	"black":     ColorBlack,
+	"filled":    ColorFilled,
+}
+
+// Code returns the character code of the color tile.
+func (c Color) Code() int {
+	return int(c)
+}
+
+func (c Color) String() string {
+	switch c {
+	case Black:
+		return "blank"
+	case ColorRed:
+		return "red"
+	case ColorOrange:
+		return "orange"
+	case ColorYellow:
+		return "yellow"
+	case ColorGreen:
+		return "green"
+	case ColorBlue:
+		return "blue"
+	case ColorViolet:
+		return "violet"
+	case ColorWhite:
+		return "white"
+	case ColorBlack:
+		return "black"
+	case ColorFilled:
+		return "filled"
+	}
+	return fmt.Sprintf("Color(%d)", int(c))
+}
+
+// IsLight returns true for the colors that dark text reads best on, i.e. to
+// pick the text color of an overlay on a colored background. ColorFilled is
+// treated as light, as it is white on the standard black board.
+func (c Color) IsLight() bool {
+	switch c {
+	case ColorOrange, ColorYellow, ColorWhite, ColorFilled:
+		return true
+	}
+	return false
+}
+
+// valid returns true if c is one of the color tiles.
+func (c Color) valid() bool {
+	return c >= ColorRed && c <= ColorFilled
+}
+
+// ParseColor returns the color for a human readable name such as "red" or
+// "paris blue". Names are case insensitive.
+func ParseColor(name string) (Color, error) {
+	key := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+	c, ok := colorNames[key]
+	if !ok {
+		return Black, fmt.Errorf("unknown color %q: %w", name, ErrInvalidColor)
+	}
+	return c, nil
+}
+
 var (
 	ErrInvalidCharacter = errors.New("invalid character")
 	ErrInvalidColor     = errors.New("invalid color")
+	ErrInvalidCode      = errors.New("invalid character code")
 
 	charNumbers map[string]int
+	codeChars   map[int]string
 )
 
 func init() {
 	charNumbers = make(map[string]int)
+	codeChars = make(map[int]string)
 	for i, c := range PrintableChars {
 		if _, ok := charNumbers[string(c)]; ok {
 			// skip spaces.
 			continue
 		}
 		charNumbers[string(c)] = i
+		codeChars[i] = string(c)
 	}
+	// Characters that are commonly typed in place of the degree sign.
+	for _, c := range degreeAliases {
+		charNumbers[string(c)] = CodeDegree
+	}
+}
+
+// ValidCode returns true if the code is a printable character, a color, or
+// blank.
+func ValidCode(code int) bool {
+	if Color(code).valid() {
+		return true
+	}
+	_, ok := codeChars[code]
+	return ok
+}
+
+// ValidCodes checks that every code is valid, the error for an invalid code
+// includes its index.
+func ValidCodes(codes ...int) error {
+	for i, code := range codes {
+		if !ValidCode(code) {
+			return fmt.Errorf("index %d: code %d: %w", i, code, ErrInvalidCode)
+		}
+	}
+	return nil
 }
 
 func CharToCode(c string) (int, error) {
@@ -63,6 +196,49 @@ func CharToCode(c string) (int, error) {
 	return i, nil
 }
 
+// EncodeRune returns the character code for r and whether r can be shown on
+// the board. Lowercase letters map to their uppercase code.
+func EncodeRune(r rune) (int, bool) {
+	if r >= 'a' && r <= 'z' {
+		r -= 'a' - 'A'
+	}
+	code, ok := charNumbers[string(r)]
+	return code, ok
+}
+
+// DecodeCode returns the character for a code. Color tiles and unused codes
+// return false.
+func DecodeCode(code int) (rune, bool) {
+	c, ok := codeChars[code]
+	if !ok {
+		return 0, false
+	}
+	r, _ := utf8.DecodeRuneInString(c)
+	return r, true
+}
+
+// colorTileRune stands in for color tiles when decoding a layout to text.
+const colorTileRune = '█'
+
+// Decode returns the text of a layout, one line per row. Color tiles are shown
+// as a block character.
+func Decode(l Layout) string {
+	var b strings.Builder
+	for x, row := range l {
+		if x > 0 {
+			b.WriteByte('\n')
+		}
+		for _, code := range row {
+			r, ok := DecodeCode(code)
+			if !ok {
+				r = colorTileRune
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 func ValidText(t string, newlineAccepted bool) error {
 	for i, c := range t {
 		if newlineAccepted && c == '\n' {
@@ -48,3 +48,210 @@ func TestCharIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]Color{
+		"red":        ColorRed,
+		"Poppy Red":  ColorRed,
+		"ORANGE":     ColorOrange,
+		"yellow":     ColorYellow,
+		"green":      ColorGreen,
+		"paris-blue": ColorBlue,
+		"violet":     ColorViolet,
+		"white":      ColorWhite,
+		"black":      ColorBlack,
+		"filled":     ColorFilled,
+	}
+	for name, want := range cases {
+		got, err := ParseColor(name)
+		if err != nil {
+			t.Errorf("ParseColor(%q): unexpected error: %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseColor(%q), want: %v, got: %v", name, want, got)
+		}
+		if back, err := ParseColor(got.String()); err != nil || back != got {
+			t.Errorf("ParseColor(%q) didn't round trip, got: %v, %v", got.String(), back, err)
+		}
+	}
+
+	if _, err := ParseColor("chartreuse"); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("wrong error: %v", err)
+	}
+
+	if got := ColorRed.Code(); got != 63 {
+		t.Errorf("wrong code for red, want: 63, got: %d", got)
+	}
+}
+
+func TestColorCodes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		color Color
+		name  string
+		code  int
+		light bool
+	}{
+		{color: Black, name: "blank", code: 0},
+		{color: ColorRed, name: "red", code: 63},
+		{color: ColorOrange, name: "orange", code: 64, light: true},
+		{color: ColorYellow, name: "yellow", code: 65, light: true},
+		{color: ColorGreen, name: "green", code: 66},
+		{color: ColorBlue, name: "blue", code: 67},
+		{color: ColorViolet, name: "violet", code: 68},
+		{color: ColorWhite, name: "white", code: 69, light: true},
+		{color: ColorBlack, name: "black", code: 70},
+		{color: ColorFilled, name: "filled", code: 71, light: true},
+	}
+
+	for _, tc := range cases {
+		if got := tc.color.Code(); got != tc.code {
+			t.Errorf("%s: wrong code, want: %d, got: %d", tc.name, tc.code, got)
+		}
+		if got := tc.color.String(); got != tc.name {
+			t.Errorf("%d: wrong name, want: %q, got: %q", tc.code, tc.name, got)
+		}
+		if got, err := ParseColor(tc.name); err != nil || got != tc.color {
+			t.Errorf("ParseColor(%q), want: %v, got: %v, err: %v", tc.name, tc.color, got, err)
+		}
+		if got := tc.color.IsLight(); got != tc.light {
+			t.Errorf("%s: IsLight, want: %v, got: %v", tc.name, tc.light, got)
+		}
+	}
+}
+
+func TestEncodeDecodeRune(t *testing.T) {
+	t.Parallel()
+
+	for _, r := range "ABCXYZ0123456789!?°" {
+		code, ok := EncodeRune(r)
+		if !ok {
+			t.Errorf("EncodeRune(%q) not ok", r)
+			continue
+		}
+		got, ok := DecodeCode(code)
+		if !ok || got != r {
+			t.Errorf("DecodeCode(%d), want: %q, got: %q, %v", code, r, got, ok)
+		}
+	}
+
+	if code, ok := EncodeRune('q'); !ok || code != 17 {
+		t.Errorf("EncodeRune('q'), want: 17, got: %d, %v", code, ok)
+	}
+	for _, r := range "é🙂ı\t" {
+		if _, ok := EncodeRune(r); ok {
+			t.Errorf("EncodeRune(%q) should not be ok", r)
+		}
+	}
+	for _, code := range []int{-1, 43, int(ColorRed), 100} {
+		if _, ok := DecodeCode(code); ok {
+			t.Errorf("DecodeCode(%d) should not be ok", code)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	l.Print(0, 0, "HI")
+	l.SetColor(1, 0, ColorRed)
+
+	lines := strings.Split(Decode(l), "\n")
+	if len(lines) != Rows {
+		t.Fatalf("wrong number of lines: %d", len(lines))
+	}
+	if want := "HI" + strings.Repeat(" ", Columns-2); lines[0] != want {
+		t.Errorf("line 0, want: %q, got: %q", want, lines[0])
+	}
+	if want := "█" + strings.Repeat(" ", Columns-1); lines[1] != want {
+		t.Errorf("line 1, want: %q, got: %q", want, lines[1])
+	}
+}
+
+func TestSymbolCodes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		r    rune
+		code int
+	}{
+		{' ', 0}, {'!', 37}, {'@', 38}, {'#', 39}, {'$', 40}, {'(', 41},
+		{')', 42}, {'-', 44}, {'+', 46}, {'&', 47}, {'=', 48}, {';', 49},
+		{':', 50}, {'\'', 52}, {'"', 53}, {'%', 54}, {',', 55}, {'.', 56},
+		{'/', 59}, {'?', 60}, {'°', CodeDegree},
+	}
+	for _, tc := range cases {
+		code, ok := EncodeRune(tc.r)
+		if !ok || code != tc.code {
+			t.Errorf("EncodeRune(%q), want: %d, got: %d, %v", tc.r, tc.code, code, ok)
+			continue
+		}
+		r, ok := DecodeCode(code)
+		if !ok || r != tc.r {
+			t.Errorf("DecodeCode(%d), want: %q, got: %q, %v", code, tc.r, r, ok)
+		}
+	}
+
+	for _, r := range degreeAliases {
+		if code, ok := EncodeRune(r); !ok || code != CodeDegree {
+			t.Errorf("EncodeRune(%q), want: %d, got: %d, %v", r, CodeDegree, code, ok)
+		}
+	}
+}
+
+func TestDegreeSign(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"72°F", "72ºF"} {
+		l, err := Compose(in, WithAlign(AlignLeft), WithVAlign(VAlignTop))
+		if err != nil {
+			t.Errorf("Compose(%q): unexpected error: %v", in, err)
+			continue
+		}
+		if l[0][2] != CodeDegree {
+			t.Errorf("Compose(%q): degree sign dropped: %v", in, l[0])
+		}
+
+		got, err := SanitizeText(in, SanitizeDrop)
+		if err != nil {
+			t.Errorf("SanitizeText(%q): unexpected error: %v", in, err)
+		}
+		if got != strings.ToUpper(in) {
+			t.Errorf("SanitizeText(%q), got: %q", in, got)
+		}
+	}
+}
+
+func TestValidCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []int{CodeBlank, 1, 26, 60, int(ColorRed), int(ColorBlack)} {
+		if !ValidCode(code) {
+			t.Errorf("code %d should be valid", code)
+		}
+	}
+	for _, code := range []int{-1, 43, 57, 72, 1000} {
+		if ValidCode(code) {
+			t.Errorf("code %d should be invalid", code)
+		}
+	}
+
+	if err := ValidCodes(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidCodes(8, 9, int(ColorGreen)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := ValidCodes(8, 9, 99, -1)
+	if !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if want := "index 2: code 99: invalid character code"; err.Error() != want {
+		t.Errorf("wrong message, want: %q, got: %q", want, err.Error())
+	}
+}
@@ -15,12 +15,18 @@
 package vestaboard
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -28,50 +34,347 @@ const (
 	APIKeySecret = "X-Vestaboard-Api-Secret"
 
 	MaxBodySize = 2_000_000
+
+	// DefaultErrorBodyLimit is how many bytes of a response body are included
+	// in error messages.
+	DefaultErrorBodyLimit = 512
+
+	// DefaultTimeout is the time a request may take when its context has no
+	// deadline.
+	DefaultTimeout = 5 * time.Second
+
+	defaultBaseURL = "https://platform.vestaboard.com"
 )
 
 type Client struct {
-	apiKey     string
-	apiSecret  string
+	baseClient
+}
+
+// New creates a client for the Vestaboard platform API. Options are applied in
+// order, if none are given the production API is used and requests without a
+// context deadline time out after DefaultTimeout.
+func New(apiKey, apiSecret string, opts ...Option) *Client {
+	return &Client{
+		baseClient: newBaseClient(options{
+			baseURL:   defaultBaseURL,
+			keys:      map[string]string{APIKeyHeader: apiKey, APIKeySecret: apiSecret},
+			rateLimit: DefaultRateLimit,
+			rateBurst: 1,
+		}, opts),
+	}
+}
+
+// baseClient holds the HTTP plumbing that is common to all API clients.
+type baseClient struct {
 	httpClient *http.Client
 	baseURL    string
+	userAgent  string
+	timeout    time.Duration
+	retry      retryPolicy
+	limiter    *rate.Limiter
+	observer   Observer
+	tracer     trace.Tracer
+
+	// acceptContentTypes are the lower case media types decoded as JSON.
+	acceptContentTypes []string
+
+	// dryRun is where messages are rendered instead of being sent, if set.
+	dryRun io.Writer
+
+	// failOnReadError stops SendIfChanged from sending when reading fails.
+	failOnReadError bool
+
+	clock Clock
+
+	// compression gzips requests and responses.
+	compression bool
+
+	// quiet is the window in which messages aren't sent, if set.
+	quiet *quietHours
+
+	errorBodyLimit int
+
+	sanitizeMode SanitizeMode
+	sanitizeOpts []SanitizeOption
 }
 
-func New(apiKey, apiSecret string) *Client {
-	return &Client{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
-		},
-		baseURL: "https://platform.vestaboard.com",
+// newBaseClient applies opts on top of the client specific defaults.
+func newBaseClient(defaults options, opts []Option) baseClient {
+	o := &defaults
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		// No client timeout, the request context is the single source of truth.
+		httpClient = &http.Client{}
+	}
+
+	// The order of the transports is documented on WithTransport.
+	if o.transport != nil {
+		hc := *httpClient
+		hc.Transport = o.transport
+		httpClient = &hc
+	}
+	if o.insecure {
+		hc := *httpClient
+		hc.Transport = insecureTransport(hc.Transport)
+		httpClient = &hc
+	}
+	if o.tuning != nil {
+		hc := *httpClient
+		hc.Transport = tunedTransport(hc.Transport, *o.tuning)
+		httpClient = &hc
+	}
+
+	timeout := DefaultTimeout
+	if o.timeout > 0 {
+		timeout = o.timeout
+	}
+	if o.noTimeout {
+		timeout = 0
+	}
+	if o.debugOut != nil {
+		hc := *httpClient
+		hc.Transport = newLoggingTransport(hc.Transport, o.debugOut)
+		httpClient = &hc
+	}
+	if o.logTransport != nil {
+		hc := *httpClient
+		hc.Transport = o.logTransport(hc.Transport)
+		httpClient = &hc
+	}
+	if len(o.keys) > 0 {
+		// Outside of the logging transport, so that the keys are redacted.
+		hc := *httpClient
+		hc.Transport = newKeyTransport(hc.Transport, o.keys)
+		httpClient = &hc
+	}
+
+	userAgent := o.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	observer := o.observer
+	if observer == nil {
+		observer = NopObserver{}
+	}
+
+	clock := o.clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	errorBodyLimit := DefaultErrorBodyLimit
+	if o.errorBodyLimit > 0 {
+		errorBodyLimit = o.errorBodyLimit
+	}
+
+	var quiet *quietHours
+	if o.quietSet {
+		quiet = newQuietHours(o.quietStart, o.quietEnd, o.quietLoc, o.quietQueue)
+	}
+
+	accept := []string{"application/json"}
+	for _, t := range o.acceptContentTypes {
+		accept = append(accept, strings.ToLower(strings.TrimSpace(t)))
+	}
+
+	return baseClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(o.baseURL, "/"),
+		userAgent:  userAgent,
+		timeout:    timeout,
+		retry:      o.retry,
+		limiter:    o.limiter(),
+		observer:   observer,
+		tracer:     o.tracer,
+
+		acceptContentTypes: accept,
+		dryRun:             o.dryRun,
+		failOnReadError:    o.failOnReadError,
+		clock:              clock,
+		compression:        o.compression,
+		quiet:              quiet,
+		errorBodyLimit:     errorBodyLimit,
+
+		sanitizeMode: o.sanitizeMode,
+		sanitizeOpts: o.sanitizeOpts,
 	}
 }
 
-func (c *Client) do(req *http.Request, out interface{}) (*http.Response, error) {
+// prepareText sanitizes text for SendText, opts override the client's
+// settings.
+func (c *baseClient) prepareText(text string, opts ...TextOption) (string, error) {
+	o := &textOptions{
+		sanitizeMode: c.sanitizeMode,
+		sanitizeOpts: c.sanitizeOpts,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.noAutoUpper {
+		for i, r := range text {
+			if unicode.IsLower(r) {
+				return "", fmt.Errorf("invalid message: lowercase character %q at position %d: %w", string(r), i, ErrInvalidCharacter)
+			}
+		}
+	}
+	text, err := SanitizeText(text, o.sanitizeMode, o.sanitizeOpts...)
+	if err != nil {
+		return "", fmt.Errorf("invalid message: %w", err)
+	}
+	return text, nil
+}
+
+func (c *baseClient) do(req *http.Request, out interface{}) (*http.Response, error) {
+	// Nothing is sent with a context that is already done, not even the first
+	// attempt of a retried request.
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	id := CorrelationID(req.Context())
+	resp, err := c.doCorrelated(req, out, id)
+	if err != nil && id != "" {
+		return nil, fmt.Errorf("correlation ID %s: %w", id, err)
+	}
+	return resp, err
+}
+
+func (c *baseClient) doCorrelated(req *http.Request, out interface{}, id string) (*http.Response, error) {
+	req.Header.Set("User-Agent", c.userAgent)
+	if id != "" {
+		req.Header.Set(CorrelationIDHeader, id)
+	}
+	if c.compression {
+		if err := compressRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.tracer != nil {
+		return c.doTraced(req, out)
+	}
+	return c.doAttempts(req, out)
+}
+
+// doAttempts performs the request, with retries if they are enabled.
+func (c *baseClient) doAttempts(req *http.Request, out interface{}) (*http.Response, error) {
+	if c.retry.maxAttempts > 1 {
+		return c.doRetry(req, out)
+	}
+	return c.doOnce(req, out)
+}
+
+type skipRateLimitKey struct{}
+
+// withoutRateLimit marks ctx so that requests made with it don't wait for the
+// rate limiter, because the caller has already reserved a token.
+func withoutRateLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipRateLimitKey{}, true)
+}
+
+// doOnce performs a single request and decodes the JSON response into out.
+func (c *baseClient) doOnce(req *http.Request, out interface{}) (*http.Response, error) {
+	// Only requests that change the board are rate limited.
+	skip, _ := req.Context().Value(skipRateLimitKey{}).(bool)
+	if c.limiter != nil && req.Method != http.MethodGet && !skip {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limit: %w", err)
+		}
+	}
+
+	start := c.clock.Now()
+	resp, err := c.send(req, out)
+	c.observer.ObserveSend(c.clock.Now().Sub(start), statusCode(resp, err), err)
+	return resp, err
+}
+
+// send performs the HTTP request and decodes the response.
+func (c *baseClient) send(req *http.Request, out interface{}) (*http.Response, error) {
+	// Apply the fallback timeout after waiting for the rate limit, so that only
+	// the request itself is limited.
+	if _, ok := req.Context().Deadline(); !ok && c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	errPrefix := fmt.Sprintf("%s %s - %d", strings.ToUpper(req.Method), req.URL.String(), resp.StatusCode)
+	method := strings.ToUpper(req.Method)
+	errPrefix := fmt.Sprintf("%s %s - %d", method, req.URL.String(), resp.StatusCode)
 
-	r := io.LimitReader(resp.Body, MaxBodySize)
+	r, err := responseReader(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
 	body, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to read body: %w", errPrefix, err)
 	}
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Method:     method,
+			URL:        req.URL.String(),
+			Body:       body,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), c.clock.Now()),
+			bodyLimit:  c.errorBodyLimit,
+		}
+	}
+
+	// Some endpoints have no meaningful response body.
+	if out == nil {
+		return resp, nil
+	}
+	// The caller wants the body as is.
+	if raw, ok := out.(*[]byte); ok {
+		*raw = body
+		return resp, nil
+	}
+
 	ct := resp.Header.Get("Content-Type")
-	if !strings.HasPrefix(ct, "application/json") {
-		return nil, fmt.Errorf("%s: response content-type is not application/json (got %s): body: %s",
-			errPrefix, ct, body)
+	if !c.acceptsContentType(ct) {
+		return nil, fmt.Errorf("%s: unexpected response content-type %q: body: %s",
+			errPrefix, ct, truncateBody(body, c.errorBodyLimit))
 	}
 
 	if err := json.Unmarshal(body, out); err != nil {
 		return nil, fmt.Errorf("%s: failed to decode JSON response: %w: body: %s",
-			errPrefix, err, body)
+			errPrefix, err, truncateBody(body, c.errorBodyLimit))
 	}
 	return resp, nil
 }
+
+// acceptsContentType returns true if a response with the Content-Type header
+// ct is decoded as JSON.
+func (c *baseClient) acceptsContentType(ct string) bool {
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	for _, t := range c.acceptContentTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateBody returns at most n bytes of body for use in an error message.
+func truncateBody(body []byte, n int) string {
+	if len(body) <= n {
+		return string(body)
+	}
+	return string(body[:n]) + "..."
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewDefaults(t *testing.T) {
+	t.Parallel()
+
+	c := New("key", "secret")
+	if c.baseURL != defaultBaseURL {
+		t.Errorf("wrong base URL, want: %q, got: %q", defaultBaseURL, c.baseURL)
+	}
+	if c.timeout != DefaultTimeout {
+		t.Errorf("wrong timeout, want: %v, got: %v", DefaultTimeout, c.timeout)
+	}
+	if !strings.HasPrefix(c.userAgent, "go-vestaboard/"+Version+" (go") {
+		t.Errorf("wrong default user agent: %q", c.userAgent)
+	}
+	if c.httpClient.Timeout != 0 {
+		t.Errorf("HTTP client should not have a timeout, got: %v", c.httpClient.Timeout)
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	var gotReq *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotReq = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type":"subscription","_id":"abc"}`))
+	}))
+	defer srv.Close()
+
+	hc := srv.Client()
+	transport := hc.Transport
+	c := New("key", "secret",
+		WithHTTPClient(hc),
+		WithBaseURL(srv.URL+"/"),
+		WithTimeout(time.Minute),
+		WithUserAgent("test-agent"))
+
+	// The provided client is copied to add the key transport, not modified.
+	if kt, ok := c.httpClient.Transport.(*keyTransport); !ok || kt.next != transport {
+		t.Errorf("provided HTTP client was not used")
+	}
+	if hc.Transport != transport {
+		t.Errorf("provided HTTP client was modified")
+	}
+	if c.timeout != time.Minute {
+		t.Errorf("wrong timeout, want: %v, got: %v", time.Minute, c.timeout)
+	}
+
+	viewer, err := c.Viewer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if viewer.ID != "abc" {
+		t.Errorf("wrong viewer ID, want: %q, got: %q", "abc", viewer.ID)
+	}
+
+	if gotReq.URL.Path != viewerPath {
+		t.Errorf("wrong path, want: %q, got: %q", viewerPath, gotReq.URL.Path)
+	}
+	if got := gotReq.Header.Get("User-Agent"); got != "test-agent" {
+		t.Errorf("wrong user agent, want: %q, got: %q", "test-agent", got)
+	}
+	if got := gotReq.Header.Get(APIKeyHeader); got != "key" {
+		t.Errorf("wrong API key, want: %q, got: %q", "key", got)
+	}
+	if got := gotReq.Header.Get(APIKeySecret); got != "secret" {
+		t.Errorf("wrong API secret, want: %q, got: %q", "secret", got)
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// The fallback applies when the context has no deadline.
+	c := New("key", "secret", WithBaseURL(srv.URL), WithTimeout(20*time.Millisecond))
+	if _, err := c.Viewer(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+
+	// A context deadline overrides the fallback.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.Viewer(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	c = New("key", "secret", WithBaseURL(srv.URL), WithTimeout(20*time.Millisecond), WithNoClientTimeout())
+	if _, err := c.Viewer(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestContentTypes(t *testing.T) {
+	t.Parallel()
+
+	html := "<html>" + strings.Repeat("x", 2*DefaultErrorBodyLimit) + "</html>"
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		opts        []Option
+		wantErr     string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"_id":"abc"}`,
+		},
+		{
+			name:        "html",
+			contentType: "text/html",
+			body:        html,
+			wantErr:     `unexpected response content-type "text/html"`,
+		},
+		{
+			name:        "text_rejected",
+			contentType: "text/plain",
+			body:        `{"_id":"abc"}`,
+			wantErr:     `unexpected response content-type "text/plain"`,
+		},
+		{
+			name:        "text_accepted",
+			contentType: "text/plain; charset=utf-8",
+			body:        `{"_id":"abc"}`,
+			opts:        []Option{WithAcceptContentTypes("Text/Plain")},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			c := New("key", "secret", append([]Option{WithBaseURL(srv.URL)}, tc.opts...)...)
+			viewer, err := c.Viewer(context.Background())
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.wantErr, err)
+				}
+				if len(err.Error()) > 2*DefaultErrorBodyLimit {
+					t.Errorf("body was not truncated: %d bytes", len(err.Error()))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if viewer.ID != "abc" {
+				t.Errorf("wrong viewer ID: %q", viewer.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock on which every delay elapses immediately, the delays
+// are recorded and advance the time.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Sleep(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.delays = append(c.delays, d)
+}
+
+func (c *fakeClock) Delays() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.delays...)
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &fakeClock{}
+		var calls int32
+		c := newRWTestClient(t, statusSequence(t, &calls, 503, 503), WithRetry(3, 20*time.Second), WithClock(clock))
+		if _, err := c.SendText(context.Background(), "HELLO"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 3 {
+			t.Errorf("expected 3 calls, got %d", n)
+		}
+		if d := clock.Delays(); len(d) != 2 || d[0] < 10*time.Second || d[1] < 20*time.Second {
+			t.Errorf("wrong backoff delays: %v", d)
+		}
+	})
+
+	t.Run("temporary", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &fakeClock{}
+		h := &boardHandler{t: t}
+		c := newRWTestClient(t, h.ServeHTTP, WithClock(clock))
+		if err := c.WithTemporary(context.Background(), NewLayout(), 24*time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d := clock.Delays(); len(d) != 1 || d[0] != 24*time.Hour {
+			t.Errorf("wrong delays: %v", d)
+		}
+	})
+
+	t.Run("retry_after", func(t *testing.T) {
+		t.Parallel()
+
+		// An HTTP date is resolved against the injected clock.
+		clock := &fakeClock{now: time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC)}
+		var calls int32
+		c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "Sat, 06 Mar 2021 00:10:00 GMT")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status":"ok"}`))
+		}, WithRetry(2, time.Second), WithClock(clock))
+		if _, err := c.SendText(context.Background(), "HELLO"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d := clock.Delays(); len(d) != 1 || d[0] != 10*time.Minute {
+			t.Errorf("wrong delays: %v", d)
+		}
+	})
+}
@@ -0,0 +1,441 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrWordTooLong = errors.New("word too long")
+
+// Align is the horizontal alignment of text within a row.
+type Align int
+
+const (
+	AlignCenter Align = iota
+	AlignLeft
+	AlignRight
+)
+
+// VAlign is the vertical alignment of text on the board.
+type VAlign int
+
+const (
+	VAlignMiddle VAlign = iota
+	VAlignTop
+	VAlignBottom
+)
+
+// Overflow is what ComposeFrames does with text that needs more rows than the
+// board has.
+type Overflow int
+
+const (
+	// OverflowError returns ErrMessageTruncated, like Compose.
+	OverflowError Overflow = iota
+	// OverflowScroll returns a frame per row, each moving the text up by one
+	// row, for PlayFrames.
+	OverflowScroll
+	// OverflowPaginate returns full pages like Paginate, for PlayPages.
+	OverflowPaginate
+)
+
+// ComposeOption configures Compose.
+type ComposeOption func(*composeOptions)
+
+type composeOptions struct {
+	align       Align
+	valign      VAlign
+	emojiColors bool
+	overflow    Overflow
+	// width is the number of columns text is wrapped at, Columns if unset.
+	width int
+
+	spaceCode int
+	spaceSet  bool
+}
+
+// WithAlign sets the horizontal alignment of each line, the default is
+// AlignCenter.
+func WithAlign(a Align) ComposeOption {
+	return func(o *composeOptions) {
+		o.align = a
+	}
+}
+
+// WithVAlign sets the vertical alignment of the block of text, the default is
+// VAlignMiddle.
+func WithVAlign(a VAlign) ComposeOption {
+	return func(o *composeOptions) {
+		o.valign = a
+	}
+}
+
+// WithEmojiColors shows colored circle and square emoji, i.e. 🔴 or 🟩, as the
+// matching color tile. Other emoji are still invalid characters, use
+// SanitizeText with WithEmojiPassthrough to drop or replace them first.
+func WithEmojiColors(enabled bool) ComposeOption {
+	return func(o *composeOptions) {
+		o.emojiColors = enabled
+	}
+}
+
+// WithSpaceCode sets the code of blank cells, both spaces in the text and the
+// padding around it, i.e. a color code to show text on a solid background.
+// The default is CodeBlank. An invalid code returns ErrInvalidCode.
+func WithSpaceCode(code int) ComposeOption {
+	return func(o *composeOptions) {
+		o.spaceCode = code
+		o.spaceSet = true
+	}
+}
+
+// WithOverflow sets what ComposeFrames does with text that doesn't fit on the
+// board, the default is OverflowError. Compose always returns an error.
+func WithOverflow(mode Overflow) ComposeOption {
+	return func(o *composeOptions) {
+		o.overflow = mode
+	}
+}
+
+// emojiColors are the emoji that WithEmojiColors shows as color tiles.
+var emojiColors = map[rune]Color{
+	'🔴': ColorRed,
+	'🟥': ColorRed,
+	'🟠': ColorOrange,
+	'🟧': ColorOrange,
+	'🟡': ColorYellow,
+	'🟨': ColorYellow,
+	'🟢': ColorGreen,
+	'🟩': ColorGreen,
+	'🔵': ColorBlue,
+	'🟦': ColorBlue,
+	'🟣': ColorViolet,
+	'🟪': ColorViolet,
+	'⚪': ColorWhite,
+	'⬜': ColorWhite,
+	'⚫': ColorBlack,
+	'⬛': ColorBlack,
+}
+
+// emojiVariation is the variation selector that may follow an emoji, it is
+// dropped along with emoji colors.
+const emojiVariation = "\uFE0F"
+
+func newComposeOptions(opts []ComposeOption) *composeOptions {
+	o := &composeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Compose renders text onto a layout. The text is uppercased and word wrapped
+// at the width of the board, newlines start a new row and an empty line leaves
+// a blank row. Words that don't fit on a single row return an
+// ErrWordTooLong error, text that needs more rows than the board has returns
+// ErrMessageTruncated.
+func Compose(text string, opts ...ComposeOption) (Layout, error) {
+	o := newComposeOptions(opts)
+
+	lines, err := o.lines(text)
+	if err != nil {
+		return NewLayout(), err
+	}
+	if len(lines) > Rows {
+		return NewLayout(), fmt.Errorf("text needs %d rows: %w", len(lines), ErrMessageTruncated)
+	}
+
+	return renderLines(lines, o)
+}
+
+// Paginate word wraps text like Compose, but instead of failing when the text
+// needs more rows than the board has, it is split into as many full pages as
+// needed. Each page is aligned according to opts. Words that don't fit on a
+// single row return an ErrWordTooLong error.
+func Paginate(text string, opts ...ComposeOption) ([]Layout, error) {
+	o := newComposeOptions(opts)
+
+	lines, err := o.lines(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return []Layout{NewLayout()}, nil
+	}
+
+	var pages []Layout
+	for start := 0; start < len(lines); start += Rows {
+		end := start + Rows
+		if end > len(lines) {
+			end = len(lines)
+		}
+		page, err := renderLines(lines[start:end], o)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// ComposeFrames renders text like Compose, but returns the frames to play
+// instead of a single layout. Text that fits is a single frame, longer text is
+// handled according to WithOverflow, i.e. scrolled up a row at a time with
+// OverflowScroll. For OverflowScroll and OverflowPaginate only words that
+// don't fit on a single row return an error.
+func ComposeFrames(text string, opts ...ComposeOption) ([]Layout, error) {
+	o := newComposeOptions(opts)
+
+	lines, err := o.lines(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) <= Rows {
+		l, err := renderLines(lines, o)
+		if err != nil {
+			return nil, err
+		}
+		return []Layout{l}, nil
+	}
+
+	switch o.overflow {
+	case OverflowScroll:
+		frames := make([]Layout, 0, len(lines)-Rows+1)
+		for start := 0; start+Rows <= len(lines); start++ {
+			frame, err := renderLines(lines[start:start+Rows], o)
+			if err != nil {
+				return nil, err
+			}
+			frames = append(frames, frame)
+		}
+		return frames, nil
+	case OverflowPaginate:
+		return Paginate(text, opts...)
+	}
+	return nil, fmt.Errorf("text needs %d rows: %w", len(lines), ErrMessageTruncated)
+}
+
+// fillBlanks sets the blank cells of l to the space code.
+func (o *composeOptions) fillBlanks(l Layout) (Layout, error) {
+	if !o.spaceSet {
+		return l, nil
+	}
+	if !ValidCode(o.spaceCode) {
+		return NewLayout(), fmt.Errorf("space code %d: %w", o.spaceCode, ErrInvalidCode)
+	}
+	for x, row := range l {
+		for y, code := range row {
+			if code == CodeBlank {
+				l[x][y] = o.spaceCode
+			}
+		}
+	}
+	return l, nil
+}
+
+// lines uppercases and validates text and wraps it at the width of the board.
+func (o *composeOptions) lines(text string) ([]string, error) {
+	text = strings.ToUpper(strings.ReplaceAll(text, "\r\n", "\n"))
+	if o.emojiColors {
+		text = strings.ReplaceAll(text, emojiVariation, "")
+	}
+	for i, r := range text {
+		if _, ok := o.encode(r); !ok && r != '\n' {
+			return nil, fmt.Errorf("invalid character %q at position %d, %w", string(r), i, ErrInvalidCharacter)
+		}
+	}
+	width := Columns
+	if o.width > 0 {
+		width = o.width
+	}
+	return wrapText(text, width)
+}
+
+// encode returns the code for r and whether it can be composed.
+func (o *composeOptions) encode(r rune) (int, bool) {
+	if c, ok := emojiColors[r]; ok && o.emojiColors {
+		return c.Code(), true
+	}
+	code, err := CharToCode(string(r))
+	return code, err == nil
+}
+
+// CenterLine returns a layout with text centered on a single row and all other
+// rows blank.
+func CenterLine(text string, row int) (Layout, error) {
+	l := NewLayout()
+	if row < 0 || row >= Rows {
+		return l, fmt.Errorf("row %d is outside of the range 0-%d: %w", row, Rows-1, ErrInvalidCoordinate)
+	}
+
+	text = strings.ToUpper(text)
+	if n := utf8.RuneCountInString(text); n > Columns {
+		return l, fmt.Errorf("text is %d characters, the maximum is %d: %w", n, Columns, ErrMessageTruncated)
+	}
+	if err := l.Print(row, alignColumn(text, AlignCenter), text); err != nil {
+		return NewLayout(), err
+	}
+	return l, nil
+}
+
+// TwoLine returns a layout with top centered in the upper half of the board
+// and bottom centered in the lower half, i.e. for a "HELLO / WORLD" greeting.
+// Lines longer than a row return ErrMessageTruncated. Only the horizontal
+// alignment, emoji colors and space code of opts are used.
+func TwoLine(top, bottom string, opts ...ComposeOption) (Layout, error) {
+	o := newComposeOptions(opts)
+
+	l := NewLayout()
+	for _, line := range []struct {
+		row  int
+		text string
+	}{{1, top}, {4, bottom}} {
+		text := strings.ToUpper(line.text)
+		if o.emojiColors {
+			text = strings.ReplaceAll(text, emojiVariation, "")
+		}
+		if n := utf8.RuneCountInString(text); n > Columns {
+			return NewLayout(), fmt.Errorf("row %d: text is %d characters, the maximum is %d: %w", line.row, n, Columns, ErrMessageTruncated)
+		}
+
+		y := alignColumn(text, o.align)
+		for _, r := range text {
+			code, ok := o.encode(r)
+			if !ok {
+				return NewLayout(), fmt.Errorf("row %d: invalid character %q, %w", line.row, string(r), ErrInvalidCharacter)
+			}
+			l[line.row][y] = code
+			y++
+		}
+	}
+	return o.fillBlanks(l)
+}
+
+// Measure returns how many rows text needs when it is word wrapped like
+// Compose does, and the length of the widest line. A word that is too long for
+// a row is put on a line of its own, so widest is larger than Columns if the
+// text can't be composed.
+func Measure(text string) (lines int, widest int) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	wrapped := splitLines(text, Columns)
+	for _, line := range wrapped {
+		if n := utf8.RuneCountInString(line); n > widest {
+			widest = n
+		}
+	}
+	return len(wrapped), widest
+}
+
+// wrapText splits text into lines at newlines and greedily packs the words of
+// each line into lines of at most width characters. An empty line is kept as
+// an empty line, trailing newlines are ignored.
+func wrapText(text string, width int) ([]string, error) {
+	lines := splitLines(text, width)
+	for _, line := range lines {
+		// Only a single word can end up longer than the width.
+		if n := utf8.RuneCountInString(line); n > width {
+			return nil, fmt.Errorf("%q is %d characters, the maximum is %d: %w", line, n, width, ErrWordTooLong)
+		}
+	}
+	return lines, nil
+}
+
+// splitLines wraps text like wrapText, but puts words that are longer than
+// width on a line of their own instead of failing.
+func splitLines(text string, width int) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		wrapped := wrapParagraph(para, width)
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		lines = append(lines, wrapped...)
+	}
+	return lines
+}
+
+// wrapParagraph greedily packs the words of text into lines of at most width
+// characters.
+func wrapParagraph(text string, width int) []string {
+	var lines []string
+	var cur strings.Builder
+	curLen := 0
+	for _, word := range strings.Fields(text) {
+		wordLen := utf8.RuneCountInString(word)
+		if curLen > 0 && curLen+1+wordLen > width {
+			lines = append(lines, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+		if curLen > 0 {
+			cur.WriteByte(' ')
+			curLen++
+		}
+		cur.WriteString(word)
+		curLen += wordLen
+	}
+	if curLen > 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// renderLines places the already wrapped lines on a layout.
+func renderLines(lines []string, o *composeOptions) (Layout, error) {
+	l := NewLayout()
+
+	top := 0
+	switch o.valign {
+	case VAlignMiddle:
+		top = (Rows - len(lines)) / 2
+	case VAlignBottom:
+		top = Rows - len(lines)
+	}
+
+	for i, line := range lines {
+		y := alignColumn(line, o.align)
+		for _, r := range line {
+			code, ok := o.encode(r)
+			if !ok {
+				return NewLayout(), fmt.Errorf("invalid character %q, %w", string(r), ErrInvalidCharacter)
+			}
+			l[top+i][y] = code
+			y++
+		}
+	}
+	return o.fillBlanks(l)
+}
+
+// alignColumn returns the starting column for line.
+func alignColumn(line string, a Align) int {
+	n := utf8.RuneCountInString(line)
+	switch a {
+	case AlignLeft:
+		return 0
+	case AlignRight:
+		return Columns - n
+	default:
+		return (Columns - n) / 2
+	}
+}
@@ -0,0 +1,378 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rowText returns the characters of a layout row, blanks as spaces.
+func rowText(l Layout, row int) string {
+	var b strings.Builder
+	for _, code := range l[row] {
+		b.WriteString(codeChars[code])
+	}
+	return b.String()
+}
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		text string
+		opts []ComposeOption
+		want [Rows]string
+	}{
+		{
+			name: "default_centered",
+			text: "hello world",
+			want: [Rows]string{
+				"",
+				"",
+				"     HELLO WORLD      ",
+			},
+		},
+		{
+			name: "wrapped",
+			text: "the quick brown fox jumps over the lazy dog",
+			opts: []ComposeOption{WithAlign(AlignLeft), WithVAlign(VAlignTop)},
+			want: [Rows]string{
+				"THE QUICK BROWN FOX   ",
+				"JUMPS OVER THE LAZY   ",
+				"DOG                   ",
+			},
+		},
+		{
+			name: "newlines",
+			text: "first\n\nthe quick brown fox jumps\nlast\n",
+			opts: []ComposeOption{WithAlign(AlignLeft), WithVAlign(VAlignTop)},
+			want: [Rows]string{
+				"FIRST                 ",
+				"",
+				"THE QUICK BROWN FOX   ",
+				"JUMPS                 ",
+				"LAST                  ",
+			},
+		},
+		{
+			name: "crlf",
+			text: "one\r\ntwo",
+			want: [Rows]string{
+				2: "         ONE          ",
+				3: "         TWO          ",
+			},
+		},
+		{
+			name: "right_bottom",
+			text: "right",
+			opts: []ComposeOption{WithAlign(AlignRight), WithVAlign(VAlignBottom)},
+			want: [Rows]string{
+				5: "                 RIGHT",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l, err := Compose(tc.text, tc.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for row, want := range tc.want {
+				if want == "" {
+					want = strings.Repeat(" ", Columns)
+				}
+				if got := rowText(l, row); got != want {
+					t.Errorf("row %d, want: %q, got: %q", row, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Compose("a supercalifragilisticexpialidocious word")
+	if !errors.Is(err, ErrWordTooLong) {
+		t.Errorf("wrong error: %v", err)
+	} else if !strings.Contains(err.Error(), "SUPERCALIFRAGILISTICEXPIALIDOCIOUS") {
+		t.Errorf("error doesn't name the word: %v", err)
+	}
+
+	_, err = Compose(strings.Repeat("TWENTY ONE CHARACTERS ", 7))
+	if !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+
+	_, err = Compose("1\n2\n3\n4\n5\n\n7")
+	if !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+
+	_, err = Compose("no emoji 🙂")
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestCenterLine(t *testing.T) {
+	t.Parallel()
+
+	l, err := CenterLine("ok", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blank := strings.Repeat(" ", Columns)
+	for row := 0; row < Rows; row++ {
+		want := blank
+		if row == 3 {
+			want = "          OK          "
+		}
+		if got := rowText(l, row); got != want {
+			t.Errorf("row %d, want: %q, got: %q", row, want, got)
+		}
+	}
+
+	if _, err := CenterLine("ok", 6); !errors.Is(err, ErrInvalidCoordinate) {
+		t.Errorf("wrong error: %v", err)
+	} else if !strings.Contains(err.Error(), "0-5") {
+		t.Errorf("error doesn't name the valid range: %v", err)
+	}
+	if _, err := CenterLine(strings.Repeat("X", Columns+1), 0); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if _, err := CenterLine("™", 0); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestTwoLine(t *testing.T) {
+	t.Parallel()
+
+	l, err := TwoLine("hello", "world")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blank := strings.Repeat(" ", Columns)
+	want := []string{blank, "        HELLO         ", blank, blank, "        WORLD         ", blank}
+	for row, w := range want {
+		if got := rowText(l, row); got != w {
+			t.Errorf("row %d, want: %q, got: %q", row, w, got)
+		}
+	}
+
+	l, err = TwoLine("hi 🟢", "", WithAlign(AlignRight), WithEmojiColors(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := l[1][Columns-1], ColorGreen.Code(); got != want {
+		t.Errorf("wrong last cell, want: %d, got: %d", want, got)
+	}
+	if got, want := rowText(l, 1)[Columns-4:Columns-1], "HI "; got != want {
+		t.Errorf("wrong alignment, want: %q, got: %q", want, got)
+	}
+
+	if _, err := TwoLine("ok", strings.Repeat("X", Columns+1)); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if _, err := TwoLine("™", "ok"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestComposeFrames(t *testing.T) {
+	t.Parallel()
+
+	opts := []ComposeOption{WithVAlign(VAlignTop), WithAlign(AlignLeft)}
+	// One row of text per line, 8 rows.
+	text := "L0\nL1\nL2\nL3\nL4\nL5\nL6\nL7"
+	pad := strings.Repeat(" ", Columns-2)
+
+	cases := []struct {
+		name     string
+		text     string
+		overflow Overflow
+		// want is the first row of each frame.
+		want    []string
+		wantErr error
+	}{
+		{name: "fits", text: "L0\nL1", overflow: OverflowError, want: []string{"L0"}},
+		{name: "fits_scroll", text: "L0\nL1", overflow: OverflowScroll, want: []string{"L0"}},
+		{name: "error", text: text, overflow: OverflowError, wantErr: ErrMessageTruncated},
+		{name: "scroll", text: text, overflow: OverflowScroll, want: []string{"L0", "L1", "L2"}},
+		{name: "paginate", text: text, overflow: OverflowPaginate, want: []string{"L0", "L6"}},
+		{name: "word_too_long", text: strings.Repeat("X", Columns+1), overflow: OverflowScroll, wantErr: ErrWordTooLong},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			frames, err := ComposeFrames(tc.text, append(opts, WithOverflow(tc.overflow))...)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(frames) != len(tc.want) {
+				t.Fatalf("want %d frames, got %d", len(tc.want), len(frames))
+			}
+			for i, want := range tc.want {
+				if got := rowText(frames[i], 0); got != want+pad {
+					t.Errorf("frame %d: want: %q, got: %q", i, want+pad, got)
+				}
+			}
+		})
+	}
+
+	// Compose ignores the overflow mode.
+	if _, err := Compose(text, WithOverflow(OverflowScroll)); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("Compose: want error %v, got: %v", ErrMessageTruncated, err)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	// Four words per row, so 7 rows of text.
+	text := strings.Repeat("WORD ", 4*Rows+4)
+	pages, err := Paginate(text, WithVAlign(VAlignTop), WithAlign(AlignLeft))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	row := "WORD WORD WORD WORD   "
+	for i := 0; i < Rows; i++ {
+		if got := rowText(pages[0], i); got != row {
+			t.Errorf("page 0, row %d: want: %q, got: %q", i, row, got)
+		}
+	}
+	if got := rowText(pages[1], 0); got != row {
+		t.Errorf("page 1, row 0: want: %q, got: %q", row, got)
+	}
+	if got := rowText(pages[1], 1); got != strings.Repeat(" ", Columns) {
+		t.Errorf("page 1, row 1 should be blank, got: %q", got)
+	}
+
+	if _, err := Paginate("fits " + strings.Repeat("X", Columns+1)); !errors.Is(err, ErrWordTooLong) {
+		t.Errorf("expected ErrWordTooLong, got: %v", err)
+	}
+	if pages, err := Paginate(""); err != nil || len(pages) != 1 || pages[0] != NewLayout() {
+		t.Errorf("expected a single blank page, got: %v, %v", pages, err)
+	}
+}
+
+func TestComposeEmojiColors(t *testing.T) {
+	t.Parallel()
+
+	l, err := Compose("build 🟢 deploy 🔴️", WithEmojiColors(true), WithAlign(AlignLeft), WithVAlign(VAlignTop))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l[0][6] != int(ColorGreen) || l[0][15] != int(ColorRed) {
+		t.Errorf("expected color tiles for the emoji:\n%v", l)
+	}
+	if got := rowText(l, 0)[:6]; got != "BUILD " {
+		t.Errorf("wrong text: %q", got)
+	}
+
+	if _, err := Compose("build 🟢"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected emoji to be invalid without WithEmojiColors, got: %v", err)
+	}
+	if _, err := Compose("build 🙂", WithEmojiColors(true)); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected unmapped emoji to be invalid, got: %v", err)
+	}
+
+	text, err := SanitizeText("ok 🟩 🙂", SanitizeReplace, WithEmojiPassthrough())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l, err := Compose(text, WithEmojiColors(true)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if !strings.Contains(rowText(l, 2), "OK") {
+		t.Errorf("wrong layout:\n%v", l)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		text   string
+		lines  int
+		widest int
+	}{
+		{text: "", lines: 0, widest: 0},
+		{text: "hello world", lines: 1, widest: 11},
+		{text: "the quick brown fox jumps over the lazy dog", lines: 3, widest: 19},
+		{text: "one\n\nthree\n", lines: 3, widest: 5},
+		{text: "a " + strings.Repeat("x", 30) + " b", lines: 3, widest: 30},
+		{text: strings.Repeat("TWENTY ONE CHARACTERS ", 7), lines: 7, widest: 21},
+	}
+	for _, tc := range cases {
+		lines, widest := Measure(tc.text)
+		if lines != tc.lines || widest != tc.widest {
+			t.Errorf("%q: want: %d lines, %d wide, got: %d lines, %d wide", tc.text, tc.lines, tc.widest, lines, widest)
+		}
+	}
+}
+
+func TestComposeSpaceCode(t *testing.T) {
+	t.Parallel()
+
+	l, err := Compose("a b", WithSpaceCode(ColorRed.Code()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x, row := range l {
+		for y, code := range row {
+			want := ColorRed.Code()
+			switch {
+			case x == 2 && y == 9:
+				want = 1
+			case x == 2 && y == 11:
+				want = 2
+			}
+			if code != want {
+				t.Errorf("row %d, column %d: want: %d, got: %d", x, y, want, code)
+			}
+		}
+	}
+
+	// The default leaves blanks.
+	if l, err = Compose("a b", WithSpaceCode(CodeBlank)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if l[0][0] != CodeBlank {
+		t.Errorf("expected blank, got: %d", l[0][0])
+	}
+
+	if _, err := Compose("a b", WithSpaceCode(-1)); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "SQUEEZED")
+
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("wrong Accept-Encoding: %q", got)
+		}
+		if got := r.Header.Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("wrong Content-Encoding: %q", got)
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("request body isn't gzipped: %v", err)
+		}
+		var got Layout
+		if err := json.NewDecoder(zr).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if got != want {
+			t.Errorf("wrong layout: %v", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gzipped(t, `{"status":"ok","id":"zipped"}`))
+	}, WithCompression(true))
+
+	resp, err := c.SendMessage(context.Background(), want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ID != "zipped" {
+		t.Errorf("wrong response: %+v", resp)
+	}
+}
+
+func TestCompressionLimit(t *testing.T) {
+	t.Parallel()
+
+	// Compresses to a few kilobytes, but exceeds MaxBodySize once decompressed.
+	bomb := gzipped(t, strings.Repeat(" ", MaxBodySize+1)+`{"status":"ok"}`)
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(bomb)
+	}, WithCompression(true))
+
+	if _, err := c.SendText(context.Background(), "HELLO"); err == nil {
+		t.Errorf("expected the body to be cut off at MaxBodySize")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	ids := make(chan string, 2)
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ids <- r.Header.Get(CorrelationIDHeader)
+		http.Error(w, "nope", http.StatusBadRequest)
+	})
+
+	ctx := context.Background()
+	if got := CorrelationID(ctx); got != "" {
+		t.Errorf("unexpected correlation ID: %q", got)
+	}
+
+	_, err := c.SendText(WithCorrelationID(ctx, "req-42"), "hi")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "correlation ID req-42") {
+		t.Errorf("error doesn't include the correlation ID: %v", err)
+	}
+	if got := <-ids; got != "req-42" {
+		t.Errorf("wrong header, want: %q, got: %q", "req-42", got)
+	}
+
+	// Without an ID, nothing is added.
+	_, err = c.SendText(ctx, "hi")
+	if strings.Contains(err.Error(), "correlation ID") {
+		t.Errorf("unexpected correlation ID in error: %v", err)
+	}
+	if got := <-ids; got != "" {
+		t.Errorf("unexpected header: %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+)
+
+// Display is a simpler interface to a board on top of an RWClient. Text is
+// checked and composed before it is sent, so that the common cases are a
+// single call. Use the RWClient for anything else.
+//
+// It isn't called Board, as that name is already used for the boards of a
+// subscription.
+type Display struct {
+	client *RWClient
+}
+
+// NewDisplay returns a Display that controls the board of c.
+func NewDisplay(c *RWClient) *Display {
+	return &Display{client: c}
+}
+
+// ShowText shows text from the top left of the board, word wrapped like
+// Compose does. Characters are handled according to the sanitize mode of the
+// client.
+func (d *Display) ShowText(ctx context.Context, text string) error {
+	return d.show(ctx, text, WithAlign(AlignLeft), WithVAlign(VAlignTop))
+}
+
+// ShowCentered shows text in the middle of the board, with every line
+// centered.
+func (d *Display) ShowCentered(ctx context.Context, text string) error {
+	return d.show(ctx, text, WithAlign(AlignCenter), WithVAlign(VAlignMiddle))
+}
+
+func (d *Display) show(ctx context.Context, text string, opts ...ComposeOption) error {
+	text, err := d.client.prepareText(text)
+	if err != nil {
+		return err
+	}
+	l, err := Compose(text, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = d.client.SendMessage(ctx, l)
+	return err
+}
+
+// Clear blanks the board.
+func (d *Display) Clear(ctx context.Context) error {
+	_, err := d.client.Clear(ctx)
+	return err
+}
+
+// Current returns the layout the board shows, a board that has never been
+// written to is blank.
+func (d *Display) Current(ctx context.Context) (Layout, error) {
+	return d.client.Snapshot(ctx)
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDisplay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := &boardHandler{t: t}
+	d := NewDisplay(newRWTestClient(t, h.ServeHTTP))
+
+	// A board that was never written to is blank.
+	current, err := d.Current(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current != NewLayout() {
+		t.Errorf("expected a blank layout, got: %v", current)
+	}
+
+	if err := d.ShowText(ctx, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	current, err = d.Current(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := rowText(current, 0), "HELLO"+strings.Repeat(" ", Columns-5); got != want {
+		t.Errorf("wrong first row, want: %q, got: %q", want, got)
+	}
+
+	if err := d.ShowCentered(ctx, "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Clear(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	posted := h.Posted()
+	if len(posted) != 3 {
+		t.Fatalf("expected 3 posts, got: %d", len(posted))
+	}
+	want, err := Compose("HI")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if posted[1] != want {
+		t.Errorf("wrong centered layout, want: %v, got: %v", want, posted[1])
+	}
+	if posted[2] != NewLayout() {
+		t.Errorf("expected a blank layout, got: %v", posted[2])
+	}
+
+	// Text is checked before anything is sent.
+	if err := d.ShowText(ctx, "  "); !errors.Is(err, ErrEmptyMessage) {
+		t.Errorf("wrong error, want: %v, got: %v", ErrEmptyMessage, err)
+	}
+	if err := d.ShowText(ctx, strings.Repeat("X", Columns+1)); !errors.Is(err, ErrWordTooLong) {
+		t.Errorf("wrong error, want: %v, got: %v", ErrWordTooLong, err)
+	}
+	if n := len(h.Posted()); n != 3 {
+		t.Errorf("invalid text was sent, got %d posts", n)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"time"
+)
+
+// DryRunMessageID is the message ID of responses to sends in dry run mode.
+const DryRunMessageID = "dry-run"
+
+// dryRunMessage renders l to the dry run writer instead of sending it and
+// returns a response as if the send succeeded.
+func (c *baseClient) dryRunMessage(l Layout) (*MessageResponse, error) {
+	if err := l.RenderPlain(c.dryRun); err != nil {
+		return nil, fmt.Errorf("dry run: %w", err)
+	}
+
+	response := &MessageResponse{
+		Message: Message{
+			ID:      DryRunMessageID,
+			Created: int(c.clock.Now().UnixNano() / int64(time.Millisecond)),
+			Text:    "ok",
+		},
+	}
+	response.setMetadata()
+	return response, nil
+}
+
+// dryRunText renders text in dry run mode. The API formats text itself, the
+// preview uses Compose, which may differ in details.
+func (c *baseClient) dryRunText(text string) (*MessageResponse, error) {
+	l, err := Compose(text)
+	if err != nil {
+		return nil, fmt.Errorf("dry run: %w", err)
+	}
+	return c.dryRunMessage(l)
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry run: %v %v", r.Method, r.URL)
+	}, WithDryRun(&out))
+
+	l := NewLayout()
+	l.Print(0, 0, "HELLO")
+	l.SetColor(5, 21, ColorRed)
+	resp, err := c.SendMessage(context.Background(), l)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MessageID != DryRunMessageID || resp.CreatedAt.IsZero() {
+		t.Errorf("wrong response: %+v", resp)
+	}
+
+	border := "+" + strings.Repeat("-", Columns) + "+"
+	want := border + "\n" +
+		"|HELLO                 |\n" +
+		strings.Repeat("|"+strings.Repeat(" ", Columns)+"|\n", 4) +
+		"|" + strings.Repeat(" ", Columns-1) + "█|\n" +
+		border + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("wrong preview, want:\n%s\ngot:\n%s", want, got)
+	}
+
+	out.Reset()
+	if _, err := c.SendText(context.Background(), "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "HI") {
+		t.Errorf("expected text in preview:\n%s", out.String())
+	}
+
+	// Invalid layouts are still rejected.
+	l[0][0] = 99
+	if _, err := c.SendMessage(context.Background(), l); err == nil {
+		t.Errorf("expected an error for an invalid layout")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	// EnvRWKey is the environment variable NewRWClientFromEnv reads the
+	// Read/Write key from.
+	EnvRWKey = "VESTABOARD_RW_KEY"
+	// EnvAPIKey and EnvAPISecret are the environment variables
+	// NewSubscriptionClientFromEnv reads the Subscription API credentials from.
+	EnvAPIKey    = "VESTABOARD_API_KEY"
+	EnvAPISecret = "VESTABOARD_API_SECRET"
+	// EnvBaseURL optionally overrides the base URL of the API.
+	EnvBaseURL = "VESTABOARD_BASE_URL"
+)
+
+// NewRWClientFromEnv creates a client for the Read/Write API with the key in
+// EnvRWKey, and the base URL in EnvBaseURL if it is set. Options are applied
+// after the environment, so they take precedence.
+func NewRWClientFromEnv(opts ...Option) (*RWClient, error) {
+	key, err := requireEnv(EnvRWKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewRWClient(key, envOptions(opts)...), nil
+}
+
+// NewSubscriptionClientFromEnv creates a client for the Subscription API with
+// the credentials in EnvAPIKey and EnvAPISecret, and the base URL in
+// EnvBaseURL if it is set. Options are applied after the environment, so they
+// take precedence.
+func NewSubscriptionClientFromEnv(opts ...Option) (*SubscriptionClient, error) {
+	key, err := requireEnv(EnvAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := requireEnv(EnvAPISecret)
+	if err != nil {
+		return nil, err
+	}
+	return New(key, secret, envOptions(opts)...), nil
+}
+
+func requireEnv(name string) (string, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return v, nil
+}
+
+// envOptions prepends the options that are configured in the environment.
+func envOptions(opts []Option) []Option {
+	if u := os.Getenv(EnvBaseURL); u != "" {
+		return append([]Option{WithBaseURL(u)}, opts...)
+	}
+	return opts
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// The tests change the environment, so they can't run in parallel.
+
+// setenv sets an environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestNewRWClientFromEnv(t *testing.T) {
+	setenv(t, EnvRWKey, "")
+	setenv(t, EnvBaseURL, "")
+	if _, err := NewRWClientFromEnv(); err == nil || !strings.Contains(err.Error(), EnvRWKey) {
+		t.Errorf("expected an error naming %s, got: %v", EnvRWKey, err)
+	}
+
+	setenv(t, EnvRWKey, "rw-key")
+	c, err := NewRWClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.baseURL != defaultRWBaseURL {
+		t.Errorf("wrong base URL, want: %q, got: %q", defaultRWBaseURL, c.baseURL)
+	}
+	if got := c.httpClient.Transport.(*keyTransport).keys[RWAPIKeyHeader]; got != "rw-key" {
+		t.Errorf("wrong key, want: %q, got: %q", "rw-key", got)
+	}
+
+	setenv(t, EnvBaseURL, "http://localhost:8080/")
+	c, err = NewRWClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.baseURL != "http://localhost:8080" {
+		t.Errorf("wrong base URL: %q", c.baseURL)
+	}
+
+	// Options take precedence over the environment.
+	c, err = NewRWClientFromEnv(WithBaseURL("http://other"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.baseURL != "http://other" {
+		t.Errorf("wrong base URL: %q", c.baseURL)
+	}
+}
+
+func TestNewSubscriptionClientFromEnv(t *testing.T) {
+	setenv(t, EnvAPIKey, "key")
+	setenv(t, EnvAPISecret, "")
+	setenv(t, EnvBaseURL, "")
+	if _, err := NewSubscriptionClientFromEnv(); err == nil || !strings.Contains(err.Error(), EnvAPISecret) {
+		t.Errorf("expected an error naming %s, got: %v", EnvAPISecret, err)
+	}
+
+	setenv(t, EnvAPISecret, "secret")
+	c, err := NewSubscriptionClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keys := c.httpClient.Transport.(*keyTransport).keys
+	if keys[APIKeyHeader] != "key" || keys[APIKeySecret] != "secret" {
+		t.Errorf("wrong credentials: %v", keys)
+	}
+	if c.baseURL != defaultBaseURL {
+		t.Errorf("wrong base URL: %q", c.baseURL)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned when the API responds with a non-2xx status code. Use
+// errors.As to inspect it. The error message only includes the start of the
+// body, Body holds all of it.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       []byte
+
+	// RetryAfter is the delay the server asked for with a Retry-After header,
+	// in seconds or as an HTTP date. It is zero if there was none.
+	RetryAfter time.Duration
+
+	// bodyLimit is how much of Body is included in the message, if it isn't
+	// set, DefaultErrorBodyLimit is used.
+	bodyLimit int
+}
+
+func (e *APIError) Error() string {
+	limit := e.bodyLimit
+	if limit <= 0 {
+		limit = DefaultErrorBodyLimit
+	}
+	retry := ""
+	if e.RetryAfter > 0 {
+		retry = fmt.Sprintf(" (retry after %v)", e.RetryAfter)
+	}
+	return fmt.Sprintf("%s %s - %d: unexpected status code%s: body: %s",
+		e.Method, e.URL, e.StatusCode, retry, truncateBody(e.Body, limit))
+}
+
+// IsUnauthorized returns true if the API rejected the credentials.
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited returns true if the request was rejected because too many
+// requests were made.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsServerError returns true for 5xx responses.
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500 && e.StatusCode <= 599
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date. Zero is returned if the header is missing
+// or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
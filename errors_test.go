@@ -0,0 +1,158 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		status       int
+		contentType  string
+		unauthorized bool
+		rateLimited  bool
+		serverError  bool
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, contentType: "application/json", unauthorized: true},
+		{name: "method_not_allowed", status: http.StatusMethodNotAllowed, contentType: "application/json"},
+		{name: "rate_limited", status: http.StatusTooManyRequests, contentType: "text/plain", rateLimited: true},
+		{name: "bad_gateway", status: http.StatusBadGateway, contentType: "text/html", serverError: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`nope`))
+			})
+
+			_, err := c.SendText(context.Background(), "HELLO")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected APIError, got: %v", err)
+			}
+			if apiErr.StatusCode != tc.status {
+				t.Errorf("wrong status code, want: %d, got: %d", tc.status, apiErr.StatusCode)
+			}
+			if apiErr.Method != http.MethodPost {
+				t.Errorf("wrong method: %q", apiErr.Method)
+			}
+			if string(apiErr.Body) != "nope" {
+				t.Errorf("wrong body: %q", apiErr.Body)
+			}
+			if got := apiErr.IsUnauthorized(); got != tc.unauthorized {
+				t.Errorf("IsUnauthorized, want: %v, got: %v", tc.unauthorized, got)
+			}
+			if got := apiErr.IsRateLimited(); got != tc.rateLimited {
+				t.Errorf("IsRateLimited, want: %v, got: %v", tc.rateLimited, got)
+			}
+			if got := apiErr.IsServerError(); got != tc.serverError {
+				t.Errorf("IsServerError, want: %v, got: %v", tc.serverError, got)
+			}
+		})
+	}
+}
+
+func TestAPIErrorTruncatesBody(t *testing.T) {
+	t.Parallel()
+
+	page := "<html><title>502 Bad Gateway</title>" + strings.Repeat(" ", 4*DefaultErrorBodyLimit) + "</html>"
+	err := &APIError{StatusCode: http.StatusBadGateway, Method: http.MethodPost, URL: "https://example.com/", Body: []byte(page)}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "502 Bad Gateway") {
+		t.Errorf("expected the start of the page in the error: %q", msg)
+	}
+	if !strings.HasSuffix(msg, "...") || len(msg) > 2*DefaultErrorBodyLimit {
+		t.Errorf("expected a truncated body, got %d bytes", len(msg))
+	}
+	if string(err.Body) != page {
+		t.Errorf("the full body should be kept")
+	}
+}
+
+func TestAPIErrorRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := c.SendText(context.Background(), "HELLO")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got: %v", err)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("wrong RetryAfter, want: %v, got: %v", 30*time.Second, apiErr.RetryAfter)
+	}
+	if !strings.Contains(err.Error(), "retry after 30s") {
+		t.Errorf("expected the delay in the message: %v", err)
+	}
+}
+
+func TestErrorBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("x", 100)
+	cases := []struct {
+		name        string
+		status      int
+		contentType string
+	}{
+		{name: "api_error", status: http.StatusInternalServerError, contentType: "text/plain"},
+		{name: "decode_error", status: http.StatusOK, contentType: "application/json"},
+		{name: "content_type", status: http.StatusOK, contentType: "text/html"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.WriteHeader(tc.status)
+				w.Write([]byte(body))
+			}, WithErrorBodyLimit(10))
+
+			_, err := c.SendText(context.Background(), "HELLO")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if msg := err.Error(); !strings.HasSuffix(msg, ": "+strings.Repeat("x", 10)+"...") {
+				t.Errorf("expected the body to be cut off after 10 bytes: %q", msg)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && string(apiErr.Body) != body {
+				t.Errorf("the full body should be kept")
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"sync"
+)
+
+// maxFanOut is how many boards SendToAll sends to at the same time.
+const maxFanOut = 8
+
+// SendResult is the outcome of sending to one board with SendToAll.
+type SendResult struct {
+	Client   *RWClient
+	Response *MessageResponse
+	Err      error
+}
+
+// SendToAll posts l to every client concurrently. The results are in the same
+// order as clients, a failure for one board doesn't stop the others. Boards
+// that haven't been sent to when ctx is done get the context's error.
+func SendToAll(ctx context.Context, clients []*RWClient, l Layout) []SendResult {
+	results := make([]SendResult, len(clients))
+	sem := make(chan struct{}, maxFanOut)
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		results[i].Client = c
+
+		wg.Add(1)
+		go func(r *SendResult) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			r.Response, r.Err = r.Client.SendMessage(ctx, l)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSendToAll(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	l.Print(0, 0, "EVERYWHERE")
+
+	var inFlight, maxInFlight int32
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}
+	failing := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	var clients []*RWClient
+	for i := 0; i < 2*maxFanOut; i++ {
+		h := ok
+		if i == 1 {
+			h = failing
+		}
+		clients = append(clients, newRWTestClient(t, h))
+	}
+
+	results := SendToAll(context.Background(), clients, l)
+	if len(results) != len(clients) {
+		t.Fatalf("expected %d results, got %d", len(clients), len(results))
+	}
+	for i, r := range results {
+		if r.Client != clients[i] {
+			t.Errorf("result %d is for the wrong client", i)
+		}
+		if i == 1 {
+			var apiErr *APIError
+			if !errors.As(r.Err, &apiErr) {
+				t.Errorf("expected an APIError for the failing board, got: %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Response == nil {
+			t.Errorf("result %d: unexpected error: %v", i, r.Err)
+		}
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m > maxFanOut {
+		t.Errorf("expected at most %d concurrent sends, got %d", maxFanOut, m)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i, r := range SendToAll(ctx, clients, l) {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %d: expected context.Canceled, got: %v", i, r.Err)
+		}
+	}
+}
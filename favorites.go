@@ -0,0 +1,75 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const favoritesPath = "/favorites"
+
+// ErrFavoriteNotFound is returned by SendFavorite for an unknown favorite ID.
+var ErrFavoriteNotFound = errors.New("favorite not found")
+
+// Favorite is a message saved in the Vestaboard app.
+type Favorite struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Layout is nil if the API didn't return the character codes.
+	Layout *Layout `json:"characters,omitempty"`
+}
+
+type favoritesResponse struct {
+	Favorites []Favorite `json:"favorites"`
+}
+
+// Favorites returns the saved messages of the installation.
+func (c *Client) Favorites(ctx context.Context) ([]Favorite, error) {
+	url := c.baseURL + favoritesPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var response favoritesResponse
+	if _, err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+	return response.Favorites, nil
+}
+
+// SendFavorite posts the saved message with the given ID to the board of a
+// subscription. The favorites are looked up first, an unknown ID or a
+// favorite without character codes returns ErrFavoriteNotFound.
+func (c *Client) SendFavorite(ctx context.Context, subscriptionID, favoriteID string) (*MessageResponse, error) {
+	favorites, err := c.Favorites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range favorites {
+		if f.ID != favoriteID {
+			continue
+		}
+		if f.Layout == nil {
+			return nil, fmt.Errorf("favorite %q has no layout: %w", favoriteID, ErrFavoriteNotFound)
+		}
+		return c.SendMessage(ctx, subscriptionID, *f.Layout)
+	}
+	return nil, fmt.Errorf("favorite %q: %w", favoriteID, ErrFavoriteNotFound)
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFavorites(t *testing.T) {
+	t.Parallel()
+
+	saved := NewLayout()
+	saved.Print(0, 0, "GOOD MORNING")
+	encoded, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var posted []Layout
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(APIKeyHeader) != "key" || r.Header.Get(APIKeySecret) != "secret" {
+			t.Errorf("missing credentials: %v", r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/favorites":
+			w.Write([]byte(`{"favorites":[` +
+				`{"id":"fav-1","title":"Morning","characters":` + string(encoded) + `},` +
+				`{"id":"fav-2","title":"Empty"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/subscriptions/sub-id/message":
+			var got LayoutMessage
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+			posted = append(posted, got.Layout)
+			w.Write([]byte(`{"message":{"id":"msg-id","created":1234}}`))
+		default:
+			t.Errorf("wrong request: %v %v", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewSubscriptionClient("key", "secret", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	favorites, err := c.Favorites(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(favorites) != 2 {
+		t.Fatalf("expected 2 favorites, got %d", len(favorites))
+	}
+	if f := favorites[0]; f.ID != "fav-1" || f.Title != "Morning" || f.Layout == nil || *f.Layout != saved {
+		t.Errorf("wrong first favorite: %+v", f)
+	}
+	if f := favorites[1]; f.ID != "fav-2" || f.Title != "Empty" || f.Layout != nil {
+		t.Errorf("wrong second favorite: %+v", f)
+	}
+
+	resp, err := c.SendFavorite(ctx, "sub-id", "fav-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ID != "msg-id" {
+		t.Errorf("wrong response: %+v", resp)
+	}
+	if len(posted) != 1 || posted[0] != saved {
+		t.Errorf("wrong layouts posted: %v", posted)
+	}
+
+	for _, id := range []string{"fav-2", "missing"} {
+		if _, err := c.SendFavorite(ctx, "sub-id", id); !errors.Is(err, ErrFavoriteNotFound) {
+			t.Errorf("%s: want error %v, got: %v", id, ErrFavoriteNotFound, err)
+		}
+	}
+	if len(posted) != 1 {
+		t.Errorf("expected no more posts, got %d", len(posted))
+	}
+}
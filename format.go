@@ -0,0 +1,69 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatClock renders t formatted with the time layout, i.e. "15:04" or
+// time.Kitchen, centered on the board.
+func FormatClock(t time.Time, layout string) (Layout, error) {
+	return Compose(t.Format(layout))
+}
+
+// FormatDate renders t formatted with the time layout centered on the board,
+// i.e. "02.01" for DD.MM or "01/02" for MM/DD. Unlike FormatClock the output
+// isn't uppercased, so that a layout producing characters the board can't
+// show, like the "pm" of "3:04pm" or the "Jan" of "Jan 2", returns
+// ErrInvalidCharacter before anything is sent. Use uppercase layout elements
+// like "PM", or uppercase the formatted text and Compose it.
+func FormatDate(t time.Time, layout string) (Layout, error) {
+	text := t.Format(layout)
+	for i, r := range text {
+		if _, err := CharToCode(string(r)); err != nil {
+			return NewLayout(), fmt.Errorf("layout %q: character %q at position %d of %q: %w", layout, string(r), i, text, ErrInvalidCharacter)
+		}
+	}
+	return Compose(text)
+}
+
+// FormatDuration renders d as a countdown centered on the board. Durations
+// under an hour are shown as M:SS, longer ones as H:MM:SS. From 100 hours on,
+// whole days are split off, i.e. "4D 05:00:00". Negative durations get a
+// minus sign. Fractions of a second are dropped.
+func FormatDuration(d time.Duration) (Layout, error) {
+	return Compose(formatDuration(d))
+}
+
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	secs := int64(d / time.Second)
+	h, m, s := secs/3600, secs/60%60, secs%60
+	switch {
+	case h >= 100:
+		return fmt.Sprintf("%s%dD %02d:%02d:%02d", sign, h/24, h%24, m, s)
+	case h > 0:
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, h, m, s)
+	default:
+		return fmt.Sprintf("%s%d:%02d", sign, m, s)
+	}
+}
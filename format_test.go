@@ -0,0 +1,104 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "0:00"},
+		{d: 59*time.Second + 900*time.Millisecond, want: "0:59"},
+		{d: 5*time.Minute + 3*time.Second, want: "5:03"},
+		{d: -90 * time.Second, want: "-1:30"},
+		{d: 2*time.Hour + 5*time.Minute, want: "2:05:00"},
+		{d: 99*time.Hour + 59*time.Minute + 59*time.Second, want: "99:59:59"},
+		{d: 100 * time.Hour, want: "4D 04:00:00"},
+		{d: -(250*time.Hour + time.Second), want: "-10D 10:00:01"},
+		{d: math.MaxInt64, want: "106751D 23:47:16"},
+	}
+
+	for _, tc := range cases {
+		if got := formatDuration(tc.d); got != tc.want {
+			t.Errorf("%v: want: %q, got: %q", tc.d, tc.want, got)
+		}
+		l, err := FormatDuration(tc.d)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.d, err)
+			continue
+		}
+		if want, _ := Compose(tc.want); l != want {
+			t.Errorf("%v: wrong layout: %v", tc.d, l)
+		}
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2021, 3, 6, 15, 4, 0, 0, time.UTC)
+	got, err := FormatClock(ts, time.Kitchen)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "        3:04PM        "; rowText(got, 2) != want {
+		t.Errorf("want: %q, got: %q", want, rowText(got, 2))
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2021, 3, 6, 15, 4, 0, 0, time.UTC)
+	cases := []struct {
+		layout  string
+		want    string
+		wantErr error
+	}{
+		{layout: "02.01", want: "        06.03         "},
+		{layout: "01/02", want: "        03/06         "},
+		{layout: "02.01.2006 15:04", want: "   06.03.2021 15:04   "},
+		{layout: "3:04PM", want: "        3:04PM        "},
+		{layout: "3:04pm", wantErr: ErrInvalidCharacter},
+		{layout: "Jan 2", wantErr: ErrInvalidCharacter},
+		{layout: "02_01", wantErr: ErrInvalidCharacter},
+	}
+
+	for _, tc := range cases {
+		got, err := FormatDate(ts, tc.layout)
+		if tc.wantErr != nil {
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%q: want error %v, got: %v", tc.layout, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.layout, err)
+			continue
+		}
+		if rowText(got, 2) != tc.want {
+			t.Errorf("%q: want: %q, got: %q", tc.layout, tc.want, rowText(got, 2))
+		}
+	}
+}
@@ -2,4 +2,10 @@ module github.com/mikehelmick/go-vestaboard
 
 go 1.16
 
-require github.com/sethvargo/go-envconfig v0.3.5 // indirect
+require (
+	github.com/robfig/cron/v3 v3.0.1
+	github.com/sethvargo/go-envconfig v0.3.5
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
+	golang.org/x/time v0.3.0
+)
@@ -0,0 +1,53 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseGrid builds a layout from a text mockup with one line per row and one
+// character per cell. Short lines and missing rows are padded with blanks,
+// lowercase letters are uppercased. More than Rows lines or a line longer than
+// Columns characters returns ErrMessageTruncated. A trailing newline is
+// ignored. Of opts, only WithSpaceCode is used.
+func ParseGrid(s string, opts ...ComposeOption) (Layout, error) {
+	o := newComposeOptions(opts)
+	l := NewLayout()
+
+	s = strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	lines := strings.Split(s, "\n")
+	if len(lines) > Rows {
+		return l, fmt.Errorf("grid has %d lines, the maximum is %d: %w", len(lines), Rows, ErrMessageTruncated)
+	}
+
+	for x, line := range lines {
+		if n := utf8.RuneCountInString(line); n > Columns {
+			return NewLayout(), fmt.Errorf("line %d is %d characters, the maximum is %d: %w", x+1, n, Columns, ErrMessageTruncated)
+		}
+		y := 0
+		for _, r := range line {
+			code, ok := EncodeRune(r)
+			if !ok {
+				return NewLayout(), fmt.Errorf("line %d, column %d: %q: %w", x+1, y+1, r, ErrInvalidCharacter)
+			}
+			l[x][y] = code
+			y++
+		}
+	}
+	return o.fillBlanks(l)
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseGrid(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseGrid("hello\n\n   world!\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := NewLayout()
+	want.Print(0, 0, "HELLO")
+	want.Print(2, 3, "WORLD!")
+	if got != want {
+		t.Errorf("wrong layout, want:\n%v\ngot:\n%v", want, got)
+	}
+
+	// Spaces and padding use the space code.
+	got, err = ParseGrid("A B", WithSpaceCode(ColorBlue.Code()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = solid(ColorBlue.Code())
+	want[0][0], want[0][2] = 1, 2
+	if got != want {
+		t.Errorf("wrong layout, want:\n%v\ngot:\n%v", want, got)
+	}
+
+	cases := []struct {
+		name    string
+		in      string
+		opts    []ComposeOption
+		wantErr error
+	}{
+		{name: "too_many_lines", in: strings.Repeat("A\n", Rows+1), wantErr: ErrMessageTruncated},
+		{name: "line_too_long", in: strings.Repeat("A", Columns+1), wantErr: ErrMessageTruncated},
+		{name: "invalid_character", in: "OK\nTAB\tHERE", wantErr: ErrInvalidCharacter},
+		{name: "invalid_space_code", in: "OK", opts: []ComposeOption{WithSpaceCode(99)}, wantErr: ErrInvalidCode},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := ParseGrid(tc.in, tc.opts...); !errors.Is(err, tc.wantErr) {
+				t.Errorf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import "fmt"
+
+// CellChange describes a single cell that differs between two layouts.
+type CellChange struct {
+	Row  int
+	Col  int
+	From int
+	To   int
+}
+
+// Diff returns the cells that change when going from l to other, in row major
+// order.
+func (l Layout) Diff(other Layout) []CellChange {
+	var changes []CellChange
+	for x := range l {
+		for y := range l[x] {
+			if l[x][y] != other[x][y] {
+				changes = append(changes, CellChange{
+					Row:  x,
+					Col:  y,
+					From: l[x][y],
+					To:   other[x][y],
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// Equal returns true if both layouts have the same codes in every cell.
+func (l Layout) Equal(other Layout) bool {
+	return l == other
+}
+
+// solid returns a layout with every cell set to code.
+func solid(code int) Layout {
+	var l Layout
+	for x := range l {
+		for y := range l[x] {
+			l[x][y] = code
+		}
+	}
+	return l
+}
+
+// FlipHorizontal returns l mirrored left to right.
+func (l Layout) FlipHorizontal() Layout {
+	var out Layout
+	for x := range l {
+		for y := range l[x] {
+			out[x][Columns-1-y] = l[x][y]
+		}
+	}
+	return out
+}
+
+// FlipVertical returns l mirrored top to bottom.
+func (l Layout) FlipVertical() Layout {
+	var out Layout
+	for x := range l {
+		out[Rows-1-x] = l[x]
+	}
+	return out
+}
+
+// Invert returns l with the on and off colors swapped, i.e. to toggle between
+// a light and a dark version of a bitmap. Other cells are unchanged. Black can
+// be used for blank cells.
+func (l Layout) Invert(on, off Color) Layout {
+	out := l
+	for x := range out {
+		for y := range out[x] {
+			switch out[x][y] {
+			case on.Code():
+				out[x][y] = off.Code()
+			case off.Code():
+				out[x][y] = on.Code()
+			}
+		}
+	}
+	return out
+}
+
+// OverlayOption configures Overlay.
+type OverlayOption func(*overlayOptions)
+
+type overlayOptions struct {
+	transparent int
+}
+
+// WithTransparentCode sets the code of the top layout that lets l show
+// through, the default is CodeBlank.
+func WithTransparentCode(code int) OverlayOption {
+	return func(o *overlayOptions) {
+		o.transparent = code
+	}
+}
+
+// Overlay returns l with the cells of top placed over it. Blank cells of top
+// are transparent, i.e. to put text over a colored background.
+func (l Layout) Overlay(top Layout, opts ...OverlayOption) Layout {
+	o := &overlayOptions{
+		transparent: CodeBlank,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	out := l
+	for x := range top {
+		for y, code := range top[x] {
+			if code != o.transparent {
+				out[x][y] = code
+			}
+		}
+	}
+	return out
+}
+
+// Border returns l with a frame of color painted over its outer thickness
+// rows and columns, the interior is left as it is. The thickness must be at
+// least 1 and at most half the number of rows.
+func (l Layout) Border(color Color, thickness int) (Layout, error) {
+	if !color.valid() {
+		return l, fmt.Errorf("%v: %w", color, ErrInvalidColor)
+	}
+	if thickness < 1 || thickness > Rows/2 {
+		return l, fmt.Errorf("border thickness %d is outside of the range 1-%d", thickness, Rows/2)
+	}
+
+	out := l
+	for x := range out {
+		for y := range out[x] {
+			if x < thickness || x >= Rows-thickness || y < thickness || y >= Columns-thickness {
+				out[x][y] = color.Code()
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidLayout   = errors.New("invalid layout")
+	ErrRequestTooLarge = errors.New("request body too large")
+)
+
+// maxLayoutJSONSize is the size of the largest JSON encoding of a valid
+// layout, since no code has more than two digits.
+const maxLayoutJSONSize = 2 + Rows*(2+Columns*2+Columns-1) + Rows - 1
+
+// MarshalJSON encodes the layout in the wire format of the APIs, an array of
+// Rows arrays with Columns codes each.
+func (l Layout) MarshalJSON() ([]byte, error) {
+	return json.Marshal([Rows][Columns]int(l))
+}
+
+// UnmarshalJSON decodes a layout in the wire format of the APIs. Unlike the
+// default decoding of arrays, missing or extra rows and columns return an
+// ErrInvalidLayout error. The codes aren't validated, so that a layout read
+// from a board can always be decoded.
+func (l *Layout) UnmarshalJSON(data []byte) error {
+	var codes [][]int
+	if err := json.Unmarshal(data, &codes); err != nil {
+		return err
+	}
+	if len(codes) != Rows {
+		return fmt.Errorf("got %d rows, want %d: %w", len(codes), Rows, ErrInvalidLayout)
+	}
+	var out Layout
+	for x, row := range codes {
+		if len(row) != Columns {
+			return fmt.Errorf("row %d has %d columns, want %d: %w", x, len(row), Columns, ErrInvalidLayout)
+		}
+		copy(out[x][:], row)
+	}
+	*l = out
+	return nil
+}
+
+// encodeLayoutRequest encodes a request body that holds a single layout. The
+// size is checked against what a valid layout can encode to, extra is the
+// number of bytes allowed for anything that wraps the layout.
+func encodeLayoutRequest(v interface{}, extra int) (*bytes.Buffer, error) {
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	// The encoder adds a trailing newline.
+	if limit := maxLayoutJSONSize + extra + 1; b.Len() > limit {
+		return nil, fmt.Errorf("encoded layout is %d bytes, the maximum is %d: %w", b.Len(), limit, ErrRequestTooLarge)
+	}
+	return &b, nil
+}
+
+// LoadOption configures LoadLayoutJSON.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	ragged bool
+	report func(RowFix)
+}
+
+// RowFix describes a row that WithRaggedRows padded or truncated.
+type RowFix struct {
+	Row int
+	// Columns is the number of codes the row had.
+	Columns int
+}
+
+// WithRaggedRows pads rows with fewer than Columns codes with blanks and
+// truncates longer rows instead of failing. Each fixed row is passed to report,
+// if it isn't nil. The number of rows must still be exact.
+func WithRaggedRows(report func(RowFix)) LoadOption {
+	return func(o *loadOptions) {
+		o.ragged = true
+		o.report = report
+	}
+}
+
+// LoadLayoutJSON reads a layout stored as a JSON array of 6 rows of 22
+// character codes. The dimensions and codes are validated, a row of the wrong
+// length returns an ErrInvalidLayout error with its index and length.
+func LoadLayoutJSON(r io.Reader, opts ...LoadOption) (Layout, error) {
+	o := &loadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxBodySize))
+	if err != nil {
+		return NewLayout(), fmt.Errorf("failed to read layout: %w", err)
+	}
+
+	var codes [][]int
+	if err := json.Unmarshal(data, &codes); err != nil {
+		return NewLayout(), jsonError(data, err)
+	}
+	if o.ragged {
+		codes = fixRows(codes, o.report)
+	}
+	return layoutFromCodes(codes)
+}
+
+// ParseCodeString parses a layout written as the nested array of character
+// codes that the API uses, i.e. a "[[0,0,...],...]" payload copied from the
+// documentation. It is validated like LoadLayoutJSON, errors give the line and
+// column of a syntax error or the row and column of an invalid code.
+func ParseCodeString(s string) (Layout, error) {
+	return LoadLayoutJSON(strings.NewReader(s))
+}
+
+// fixRows pads or truncates every row of codes to Columns codes.
+func fixRows(codes [][]int, report func(RowFix)) [][]int {
+	fixed := make([][]int, len(codes))
+	for x, row := range codes {
+		if len(row) == Columns {
+			fixed[x] = row
+			continue
+		}
+		r := make([]int, Columns)
+		copy(r, row)
+		fixed[x] = r
+		if report != nil {
+			report(RowFix{Row: x, Columns: len(row)})
+		}
+	}
+	return fixed
+}
+
+// layoutFromCodes checks the dimensions and codes of a nested slice and copies
+// it to a Layout.
+func layoutFromCodes(codes [][]int) (Layout, error) {
+	l := NewLayout()
+	if len(codes) != Rows {
+		return l, fmt.Errorf("got %d rows, want %d: %w", len(codes), Rows, ErrInvalidLayout)
+	}
+	for x, row := range codes {
+		if len(row) != Columns {
+			return l, fmt.Errorf("row %d has %d columns, want %d: %w", x, len(row), Columns, ErrInvalidLayout)
+		}
+		copy(l[x][:], row)
+	}
+	if err := l.Validate(); err != nil {
+		return NewLayout(), err
+	}
+	return l, nil
+}
+
+// jsonError adds the line and column of a decoding error.
+func jsonError(data []byte, err error) error {
+	var offset int64
+	field := ""
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		field = typeErr.Field
+	default:
+		return fmt.Errorf("failed to decode layout: %w", err)
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	if field != "" {
+		return fmt.Errorf("failed to decode layout at line %d, column %d (field %s): %w", line, col, field, err)
+	}
+	return fmt.Errorf("failed to decode layout at line %d, column %d: %w", line, col, err)
+}
+
+// WriteJSON writes the layout as a JSON array of character codes, one row per
+// line.
+func (l Layout) WriteJSON(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("[\n")
+	for x, row := range l {
+		enc, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		b.WriteString("  ")
+		b.Write(enc)
+		if x < len(l)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("]\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// SendLayoutFile reads a layout from a JSON file and sends it to the board.
+func (c *RWClient) SendLayoutFile(ctx context.Context, path string) (*MessageResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := LoadLayoutJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c.SendMessage(ctx, l)
+}
@@ -0,0 +1,304 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLayoutJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "ROUND TRIP")
+	want.SetColor(5, 21, ColorViolet)
+
+	var b bytes.Buffer
+	if err := want.WriteJSON(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Count(b.String(), "\n"); lines != Rows+2 {
+		t.Errorf("expected one row per line, got %d lines: %s", lines, b.String())
+	}
+
+	got, err := LoadLayoutJSON(&b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("wrong layout, want: %v, got: %v", want, got)
+	}
+}
+
+func TestLoadLayoutJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	row := "[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]"
+	rows := func(r ...string) string {
+		return "[\n" + strings.Join(r, ",\n") + "\n]"
+	}
+
+	cases := []struct {
+		name    string
+		in      string
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "too_few_rows",
+			in:      rows(row, row),
+			wantErr: ErrInvalidLayout,
+			wantMsg: "got 2 rows",
+		},
+		{
+			name:    "short_row",
+			in:      rows(row, row, "[1,2,3]", row, row, row),
+			wantErr: ErrInvalidLayout,
+			wantMsg: "row 2 has 3 columns",
+		},
+		{
+			name:    "bad_code",
+			in:      rows(row, row, row, row, row, strings.Replace(row, "0", "99", 1)),
+			wantErr: ErrInvalidCode,
+			wantMsg: "row 5, column 0",
+		},
+		{
+			name:    "syntax",
+			in:      rows(row, row, "[0,0,]", row, row, row),
+			wantMsg: "line 4",
+		},
+		{
+			name:    "type",
+			in:      rows(row, `["A"]`, row, row, row, row),
+			wantMsg: "line 3",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := LoadLayoutJSON(strings.NewReader(tc.in))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), tc.wantMsg) {
+				t.Errorf("error %q does not contain %q", err, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLoadLayoutJSONRaggedRows(t *testing.T) {
+	t.Parallel()
+
+	row := "[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]"
+	long := "[" + strings.Repeat("1,", Columns) + "2]"
+	in := "[" + strings.Join([]string{row, "[8,9]", row, long, row, row}, ",") + "]"
+
+	var fixes []RowFix
+	got, err := LoadLayoutJSON(strings.NewReader(in), WithRaggedRows(func(f RowFix) {
+		fixes = append(fixes, f)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := NewLayout()
+	want[1][0], want[1][1] = 8, 9
+	for y := range want[3] {
+		want[3][y] = 1
+	}
+	if got != want {
+		t.Errorf("wrong layout, want: %v, got: %v", want, got)
+	}
+	wantFixes := []RowFix{{Row: 1, Columns: 2}, {Row: 3, Columns: Columns + 1}}
+	if !reflect.DeepEqual(fixes, wantFixes) {
+		t.Errorf("wrong fixes, want: %v, got: %v", wantFixes, fixes)
+	}
+
+	// The number of rows must still match, and a nil report is fine.
+	if _, err := LoadLayoutJSON(strings.NewReader("["+row+"]"), WithRaggedRows(nil)); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestEncodeLayoutRequest(t *testing.T) {
+	t.Parallel()
+
+	var largest Layout
+	for i := range largest {
+		for j := range largest[i] {
+			largest[i][j] = int(ColorFilled)
+		}
+	}
+	b, err := encodeLayoutRequest(largest, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Len() != maxLayoutJSONSize+1 {
+		t.Errorf("expected the largest layout to encode to %d bytes, got %d", maxLayoutJSONSize+1, b.Len())
+	}
+
+	// A layout built outside the type, i.e. through reflection, can be ragged.
+	ragged := make([][]int, Rows)
+	for i := range ragged {
+		ragged[i] = make([]int, Columns+i)
+		for j := range ragged[i] {
+			ragged[i][j] = int(ColorFilled)
+		}
+	}
+	if _, err := encodeLayoutRequest(ragged, 0); !errors.Is(err, ErrRequestTooLarge) {
+		t.Errorf("expected ErrRequestTooLarge, got: %v", err)
+	}
+
+	if _, err := encodeLayoutRequest(&LayoutMessage{Layout: largest}, len(`{"characters":}`)); err != nil {
+		t.Errorf("unexpected error for wrapped layout: %v", err)
+	}
+}
+
+// wireLayout is a Read/Write API request body in the documented wire format,
+// with HELLO in the top left and a violet cell in the bottom right.
+const wireLayout = "[[8,5,12,12,15,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]," +
+	"[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]," +
+	"[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]," +
+	"[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]," +
+	"[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]," +
+	"[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,68]]"
+
+func TestLayoutMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	if err := l.Print(0, 0, "HELLO"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetColor(5, 21, ColorViolet); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != wireLayout {
+		t.Errorf("wrong encoding\nwant: %s\ngot:  %s", wireLayout, got)
+	}
+
+	// Pointers and embedded layouts use the same encoding.
+	got, err = json.Marshal(&LayoutMessage{Layout: l})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"characters":` + wireLayout + `}`; string(got) != want {
+		t.Errorf("wrong encoding\nwant: %s\ngot:  %s", want, got)
+	}
+
+	var decoded Layout
+	if err := json.Unmarshal([]byte(wireLayout), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != l {
+		t.Errorf("wrong layout, want: %v, got: %v", l, decoded)
+	}
+}
+
+func TestLayoutUnmarshalJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	row := "[" + strings.Repeat("0,", Columns-1) + "0]"
+	rows := func(n int) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(row+",", n), ",") + "]"
+	}
+
+	cases := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "short", data: rows(Rows - 1), wantErr: ErrInvalidLayout},
+		{name: "long", data: rows(Rows + 1), wantErr: ErrInvalidLayout},
+		{name: "ragged", data: strings.Replace(rows(Rows), row, "[0,0]", 1), wantErr: ErrInvalidLayout},
+		{name: "unknown_code", data: strings.Replace(rows(Rows), "[0,", "[99,", 1)},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var l Layout
+			err := json.Unmarshal([]byte(tc.data), &l)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	var l Layout
+	if err := json.Unmarshal([]byte(`"not a layout"`), &l); err == nil {
+		t.Error("expected an error for a string")
+	}
+}
+
+func TestParseCodeString(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "HI")
+	want[5][21] = ColorRed.Code()
+
+	rows := make([]string, Rows)
+	for x, row := range want {
+		codes := make([]string, Columns)
+		for y, code := range row {
+			codes[y] = strconv.Itoa(code)
+		}
+		rows[x] = "[" + strings.Join(codes, ", ") + "]"
+	}
+
+	got, err := ParseCodeString("  [" + strings.Join(rows, ",\n") + "]\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("wrong layout:\n%v", got)
+	}
+
+	for _, tc := range []struct {
+		in      string
+		wantErr error
+	}{
+		{in: "[" + strings.Join(rows[:5], ",") + "]", wantErr: ErrInvalidLayout},
+		{in: "[" + strings.Join(rows[:5], ",") + ",[" + strings.Repeat("0,", Columns-1) + "43]]", wantErr: ErrInvalidCode},
+	} {
+		if _, err := ParseCodeString(tc.in); !errors.Is(err, tc.wantErr) {
+			t.Errorf("want error %v, got: %v", tc.wantErr, err)
+		}
+	}
+	if _, err := ParseCodeString("[[1, 2,]]"); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("want a syntax error with the position, got: %v", err)
+	}
+}
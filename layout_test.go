@@ -0,0 +1,139 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutDiff(t *testing.T) {
+	t.Parallel()
+
+	a := NewLayout()
+	a.Print(0, 0, "AB")
+	b := a
+	if !a.Equal(b) {
+		t.Errorf("expected copies to be equal")
+	}
+	if d := a.Diff(b); len(d) != 0 {
+		t.Errorf("expected no changes, got: %+v", d)
+	}
+
+	b.Print(0, 1, "C")
+	b.SetColor(5, 21, ColorRed)
+	if a.Equal(b) {
+		t.Errorf("expected layouts to differ")
+	}
+
+	want := []CellChange{
+		{Row: 0, Col: 1, From: 2, To: 3},
+		{Row: 5, Col: 21, From: 0, To: int(ColorRed)},
+	}
+	if got := a.Diff(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong diff, want: %+v, got: %+v", want, got)
+	}
+}
+
+func TestLayoutTransforms(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	l.Print(0, 0, "AB")
+	l.SetColor(5, 0, ColorRed)
+
+	h := l.FlipHorizontal()
+	if h[0][21] != l[0][0] || h[0][20] != l[0][1] || h[5][21] != int(ColorRed) || h[0][0] != CodeBlank {
+		t.Errorf("wrong horizontal flip:\n%v", h)
+	}
+	if h.FlipHorizontal() != l {
+		t.Errorf("flipping twice should restore the layout")
+	}
+
+	v := l.FlipVertical()
+	if v[5][0] != l[0][0] || v[0][0] != int(ColorRed) {
+		t.Errorf("wrong vertical flip:\n%v", v)
+	}
+	if v.FlipVertical() != l {
+		t.Errorf("flipping twice should restore the layout")
+	}
+
+	inv := l.Invert(ColorRed, Black)
+	if inv[5][0] != CodeBlank || inv[5][1] != int(ColorRed) || inv[0][0] != l[0][0] {
+		t.Errorf("wrong inversion:\n%v", inv)
+	}
+	if inv.Invert(ColorRed, Black) != l {
+		t.Errorf("inverting twice should restore the layout")
+	}
+}
+
+func TestLayoutOverlay(t *testing.T) {
+	t.Parallel()
+
+	background := solid(int(ColorBlue))
+	text, err := Compose("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := background.Overlay(text)
+	if got[2][10] != text[2][10] || got[2][11] != text[2][11] {
+		t.Errorf("expected the text on top:\n%v", got)
+	}
+	if got[0][0] != int(ColorBlue) || got[2][9] != int(ColorBlue) {
+		t.Errorf("expected blank cells to be transparent:\n%v", got)
+	}
+
+	// With another transparent code, only those cells show the background.
+	border := solid(int(ColorRed))
+	border[1][1] = int(ColorFilled)
+	got = background.Overlay(border, WithTransparentCode(int(ColorFilled)))
+	if got[0][0] != int(ColorRed) || got[1][1] != int(ColorBlue) {
+		t.Errorf("wrong overlay with a transparent code:\n%v", got)
+	}
+}
+
+func TestLayoutBorder(t *testing.T) {
+	t.Parallel()
+
+	text, err := Compose("framed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := text.Border(ColorViolet, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, c := range []Cell{{0, 0}, {1, 10}, {5, 21}, {3, 1}, {2, 20}, {4, 5}} {
+		if got[c.Row][c.Col] != int(ColorViolet) {
+			t.Errorf("expected the border at %v:\n%v", c, got)
+		}
+	}
+	if got[2][2] != CodeBlank || got[2][8] != text[2][8] || got[3][19] != CodeBlank {
+		t.Errorf("expected the interior to be unchanged:\n%v", got)
+	}
+
+	if _, err := text.Border(ColorViolet, 4); err == nil {
+		t.Errorf("expected an error for a border that is too thick")
+	}
+	if _, err := text.Border(ColorViolet, 0); err == nil {
+		t.Errorf("expected an error for a border without thickness")
+	}
+	if _, err := text.Border(Color(3), 1); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor, got: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnknownFormat is returned for a Format that LoadLayout doesn't support.
+var ErrUnknownFormat = errors.New("unknown layout format")
+
+// Format selects how LoadLayout parses its input.
+type Format int
+
+const (
+	// FormatJSON is a JSON array of 6 rows of 22 character codes, see
+	// LoadLayoutJSON.
+	FormatJSON Format = iota
+	// FormatGrid is a text mockup with one line per row, see ParseGrid.
+	FormatGrid
+	// FormatVBML is a VBML document, see ParseVBML.
+	FormatVBML
+)
+
+var formatNames = map[string]Format{
+	"json": FormatJSON,
+	"grid": FormatGrid,
+	"vbml": FormatVBML,
+}
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatGrid:
+		return "grid"
+	case FormatVBML:
+		return "vbml"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat returns the format for a name as returned by Format.String, i.e.
+// the value of a --format flag. Names are case insensitive.
+func ParseFormat(name string) (Format, error) {
+	f, ok := formatNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return FormatJSON, fmt.Errorf("%q: %w", name, ErrUnknownFormat)
+	}
+	return f, nil
+}
+
+// LoadLayout reads a layout in the given format from r, i.e. from os.Stdin in
+// a CLI. At most MaxBodySize bytes are read.
+func LoadLayout(r io.Reader, format Format) (Layout, error) {
+	var parse func(string) (Layout, error)
+	switch format {
+	case FormatJSON:
+		return LoadLayoutJSON(r)
+	case FormatGrid:
+		parse = func(s string) (Layout, error) { return ParseGrid(s) }
+	case FormatVBML:
+		parse = ParseVBML
+	default:
+		return NewLayout(), fmt.Errorf("%v: %w", format, ErrUnknownFormat)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxBodySize))
+	if err != nil {
+		return NewLayout(), fmt.Errorf("failed to read layout: %w", err)
+	}
+	return parse(string(data))
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayout(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "HI")
+
+	cases := []struct {
+		name    string
+		format  Format
+		in      string
+		wantErr error
+	}{
+		{name: "json", format: FormatJSON, in: mustJSON(t, want)},
+		{name: "grid", format: FormatGrid, in: "hi\n"},
+		{name: "vbml", format: FormatVBML, in: `{"components": [{"template": "hi", "style": {"justify": "left", "align": "top"}}]}`},
+		{name: "bad_json", format: FormatJSON, in: "[[1]]", wantErr: ErrInvalidLayout},
+		{name: "bad_grid", format: FormatGrid, in: "_", wantErr: ErrInvalidCharacter},
+		{name: "unknown", format: Format(42), in: "hi", wantErr: ErrUnknownFormat},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadLayout(strings.NewReader(tc.in), tc.format)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want error %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != want {
+				t.Errorf("wrong layout:\n%v", got)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []Format{FormatJSON, FormatGrid, FormatVBML} {
+		got, err := ParseFormat(strings.ToUpper(f.String()))
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", f, err)
+		}
+		if got != f {
+			t.Errorf("want: %v, got: %v", f, got)
+		}
+	}
+	if _, err := ParseFormat("yaml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("want error %v, got: %v", ErrUnknownFormat, err)
+	}
+}
+
+func mustJSON(t *testing.T, l Layout) string {
+	t.Helper()
+
+	b, err := l.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal layout: %v", err)
+	}
+	return string(b)
+}
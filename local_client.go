@@ -0,0 +1,160 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	LocalAPIKeyHeader           = "X-Vestaboard-Local-Api-Key"
+	LocalAPIEnablementKeyHeader = "X-Vestaboard-Local-Api-Enablement-Token"
+
+	// LocalAPIPort is the port the board serves the Local API on.
+	LocalAPIPort = "7000"
+
+	localMessagePath    = "/local-api/message"
+	localEnablementPath = "/local-api/enablement"
+)
+
+// LocalClient is a client for the Local API of a board on the local network.
+type LocalClient struct {
+	baseClient
+}
+
+// NewLocalClient creates a client for the board at host, which is an IP
+// address or hostname. If host has no scheme, http is used, and if it has no
+// port, LocalAPIPort is used. The Local API isn't rate limited by default.
+func NewLocalClient(host, localKey string, opts ...Option) *LocalClient {
+	return &LocalClient{
+		baseClient: newBaseClient(options{
+			baseURL: localBaseURL(host),
+			keys:    map[string]string{LocalAPIKeyHeader: localKey},
+		}, opts),
+	}
+}
+
+func localBaseURL(host string) string {
+	scheme := "http://"
+	if i := strings.Index(host, "://"); i >= 0 {
+		scheme, host = host[:i+3], host[i+3:]
+	}
+	host = strings.TrimSuffix(host, "/")
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), LocalAPIPort)
+	}
+	return scheme + host
+}
+
+type localReadResponse struct {
+	Message *Layout `json:"message"`
+}
+
+// ReadMessage returns the layout that is currently shown on the board.
+func (c *LocalClient) ReadMessage(ctx context.Context) (*Layout, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+localMessagePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var raw json.RawMessage
+	if _, err := c.do(req, &raw); err != nil {
+		return nil, err
+	}
+
+	// Depending on firmware, the layout is either returned directly or wrapped
+	// in a message object.
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		var l Layout
+		if err := json.Unmarshal(raw, &l); err != nil {
+			return nil, fmt.Errorf("failed to decode layout: %w", err)
+		}
+		return &l, nil
+	}
+
+	var response localReadResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode layout: %w", err)
+	}
+	if response.Message == nil {
+		return nil, ErrNoCurrentMessage
+	}
+	return response.Message, nil
+}
+
+// WriteMessage shows a layout on the board.
+func (c *LocalClient) WriteMessage(ctx context.Context, l Layout) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("invalid layout: %w", err)
+	}
+	if err := c.checkQuietHours(func(ctx context.Context) error {
+		return c.WriteMessage(ctx, l)
+	}); err != nil {
+		return err
+	}
+	if c.dryRun != nil {
+		_, err := c.dryRunMessage(l)
+		return err
+	}
+
+	b, err := encodeLayoutRequest(l, 0)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+localMessagePath, b)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// The board doesn't return a meaningful body for writes.
+	_, err = c.do(req, nil)
+	return err
+}
+
+type enablementResponse struct {
+	Message string `json:"message"`
+	APIKey  string `json:"apiKey"`
+}
+
+// EnableLocalAPI enables the Local API on the board using the enablement token
+// obtained from Vestaboard and returns the generated local API key. This only
+// needs to be done once, the client's own key isn't needed and may be empty.
+func (c *LocalClient) EnableLocalAPI(ctx context.Context, enablementToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+localEnablementPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(LocalAPIEnablementKeyHeader, enablementToken)
+
+	var response enablementResponse
+	if _, err := c.do(req, &response); err != nil {
+		return "", err
+	}
+	if response.APIKey == "" {
+		return "", errors.New("enablement response did not include an API key")
+	}
+	return response.APIKey, nil
+}
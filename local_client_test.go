@@ -0,0 +1,99 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalBaseURL(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"192.168.1.10":             "http://192.168.1.10:7000",
+		"vestaboard.local":         "http://vestaboard.local:7000",
+		"vestaboard.local:8000":    "http://vestaboard.local:8000",
+		"https://vestaboard.local": "https://vestaboard.local:7000",
+		"http://10.0.0.2:7000/":    "http://10.0.0.2:7000",
+		"fe80::1":                  "http://[fe80::1]:7000",
+	}
+	for host, want := range cases {
+		if got := localBaseURL(host); got != want {
+			t.Errorf("localBaseURL(%q), want: %q, got: %q", host, want, got)
+		}
+	}
+}
+
+func TestLocalClient(t *testing.T) {
+	t.Parallel()
+
+	var stored Layout
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case localEnablementPath:
+			if r.Header.Get(LocalAPIEnablementKeyHeader) != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"message":"Local API enabled","apiKey":"local-key"}`))
+		case localMessagePath:
+			if r.Header.Get(LocalAPIKeyHeader) != "local-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.Method == http.MethodPost {
+				if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+					t.Errorf("failed to decode body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]Layout{"message": stored})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	key, err := NewLocalClient(srv.URL, "").EnableLocalAPI(ctx, "token")
+	if err != nil {
+		t.Fatalf("unexpected error enabling: %v", err)
+	}
+	if key != "local-key" {
+		t.Fatalf("wrong key, want: %q, got: %q", "local-key", key)
+	}
+
+	c := NewLocalClient(srv.URL, key)
+	want := NewLayout()
+	want.Print(0, 0, "LOCAL")
+	if err := c.WriteMessage(ctx, want); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	got, err := c.ReadMessage(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if *got != want {
+		t.Errorf("wrong layout, want: %v, got: %v", want, *got)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+const redacted = "REDACTED"
+
+// redactedHeaders are never written out by the logging transport.
+var redactedHeaders = []string{
+	APIKeyHeader,
+	APIKeySecret,
+	RWAPIKeyHeader,
+	LocalAPIKeyHeader,
+	LocalAPIEnablementKeyHeader,
+}
+
+// loggingTransport dumps requests and responses to a writer, with credentials
+// removed.
+type loggingTransport struct {
+	next http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newLoggingTransport(next http.RoundTripper, w io.Writer) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &loggingTransport{
+		next: next,
+		w:    w,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.logRequest(req)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.printf("<<< error: %v\n\n", err)
+		return nil, err
+	}
+
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		t.printf("<<< failed to dump response: %v\n\n", err)
+		return resp, nil
+	}
+	t.printf("<<< %s\n\n", dump)
+	return resp, nil
+}
+
+func (t *loggingTransport) logRequest(req *http.Request) {
+	// Dump a copy with redacted headers, RoundTrip must not modify the request.
+	clone := req.Clone(req.Context())
+	for _, h := range redactedHeaders {
+		if clone.Header.Get(h) != "" {
+			clone.Header.Set(h, redacted)
+		}
+	}
+	clone.Body = nil
+
+	dump, err := httputil.DumpRequestOut(clone, false)
+	if err != nil {
+		t.printf(">>> failed to dump request: %v\n\n", err)
+		return
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(io.LimitReader(rc, MaxBodySize))
+			rc.Close()
+		}
+	}
+	t.printf(">>> %s%s\n\n", dump, body)
+}
+
+func (t *loggingTransport) printf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, format, args...)
+}
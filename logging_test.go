@@ -0,0 +1,61 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugLogging(t *testing.T) {
+	t.Parallel()
+
+	var gotKey string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get(APIKeyHeader)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"subscriptions":[{"_id":"sub-id"}]}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	// The logging option comes first, it must still wrap the TLS test transport.
+	c := New("very-secret-key", "very-secret-secret",
+		WithDebugLogging(&buf),
+		WithHTTPClient(srv.Client()),
+		WithBaseURL(srv.URL))
+
+	if _, err := c.Subscriptions(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotKey != "very-secret-key" {
+		t.Errorf("server received wrong key: %q", gotKey)
+	}
+
+	log := buf.String()
+	if strings.Contains(log, "very-secret") {
+		t.Errorf("credentials leaked into log: %s", log)
+	}
+	for _, want := range []string{redacted, "GET /subscriptions", "sub-id"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q: %s", want, log)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Observer is notified about requests made by a client, so that they can be
+// recorded as metrics without this package depending on a metrics library.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// ObserveSend is called after each HTTP attempt with its duration, the
+	// response status code (0 if there was no response), and the error if the
+	// attempt failed.
+	ObserveSend(duration time.Duration, statusCode int, err error)
+
+	// ObserveRetry is called before a failed request is retried. Attempt is
+	// the number of the upcoming attempt, starting at 1 for the first retry.
+	ObserveRetry(attempt int, delay time.Duration, err error)
+}
+
+// NopObserver ignores all observations. It can be embedded to implement only
+// part of Observer.
+type NopObserver struct{}
+
+func (NopObserver) ObserveSend(time.Duration, int, error) {}
+
+func (NopObserver) ObserveRetry(int, time.Duration, error) {}
+
+// statusCode returns the status of a response, or of the APIError.
+func statusCode(resp *http.Response, err error) int {
+	if resp != nil {
+		return resp.StatusCode
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type recordingObserver struct {
+	mu       sync.Mutex
+	statuses []int
+	errs     int
+	retries  []int
+}
+
+func (o *recordingObserver) ObserveSend(d time.Duration, status int, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.statuses = append(o.statuses, status)
+	if err != nil {
+		o.errs++
+	}
+}
+
+func (o *recordingObserver) ObserveRetry(attempt int, d time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.retries = append(o.retries, attempt)
+}
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := httptest.NewServer(statusSequence(t, &calls, 503, 429))
+	defer srv.Close()
+
+	obs := &recordingObserver{}
+	c := NewRWClient("key",
+		WithBaseURL(srv.URL),
+		WithRetry(3, time.Millisecond),
+		WithRateLimit(rate.Inf, 0),
+		WithObserver(obs))
+
+	if _, err := c.SendText(context.Background(), "OBSERVED"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []int{503, 429, 200}; !reflect.DeepEqual(obs.statuses, want) {
+		t.Errorf("wrong statuses, want: %v, got: %v", want, obs.statuses)
+	}
+	if obs.errs != 2 {
+		t.Errorf("wrong error count, want: 2, got: %d", obs.errs)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(obs.retries, want) {
+		t.Errorf("wrong retries, want: %v, got: %v", want, obs.retries)
+	}
+}
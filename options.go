@@ -0,0 +1,289 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimit is the rate at which the cloud APIs accept messages, one
+// every 15 seconds.
+var DefaultRateLimit = rate.Every(15 * time.Second)
+
+// Option configures a client at construction time.
+type Option func(*options)
+
+type options struct {
+	httpClient *http.Client
+	timeout    time.Duration
+	noTimeout  bool
+	baseURL    string
+	userAgent  string
+	debugOut   io.Writer
+	// logTransport wraps the transport to log requests, see WithLogger.
+	logTransport func(http.RoundTripper) http.RoundTripper
+	keys         map[string]string
+	insecure     bool
+	transport    http.RoundTripper
+	tuning       *TransportTuning
+	retry        retryPolicy
+	rateLimit    rate.Limit
+	rateBurst    int
+
+	observer Observer
+	tracer   trace.Tracer
+
+	acceptContentTypes []string
+
+	dryRun io.Writer
+
+	failOnReadError bool
+
+	clock Clock
+
+	compression bool
+
+	quietStart, quietEnd time.Time
+	quietLoc             *time.Location
+	quietQueue           bool
+	quietSet             bool
+
+	errorBodyLimit int
+
+	sanitizeMode SanitizeMode
+	sanitizeOpts []SanitizeOption
+}
+
+// limiter returns the configured rate limiter, or nil if requests aren't
+// limited.
+func (o *options) limiter() *rate.Limiter {
+	if o.rateLimit == 0 || o.rateLimit == rate.Inf {
+		return nil
+	}
+	burst := o.rateBurst
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(o.rateLimit, burst)
+}
+
+// WithHTTPClient sets the HTTP client used to make requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = hc
+	}
+}
+
+// WithTimeout sets how long each request may take if its context has no
+// deadline, the default is DefaultTimeout. A deadline on the context always
+// takes precedence.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+		o.noTimeout = false
+	}
+}
+
+// WithNoClientTimeout removes the fallback timeout, so requests are only
+// bounded by their context.
+func WithNoClientTimeout() Option {
+	return func(o *options) {
+		o.noTimeout = true
+	}
+}
+
+// WithBaseURL overrides the API endpoint, i.e. for a staging environment or a
+// test server.
+func WithBaseURL(u string) Option {
+	return func(o *options) {
+		o.baseURL = u
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every request, the default
+// is DefaultUserAgent.
+func WithUserAgent(ua string) Option {
+	return func(o *options) {
+		o.userAgent = ua
+	}
+}
+
+// WithDebugLogging writes a dump of every request and response to w. API
+// credentials are redacted. The logging wraps the transport of the configured
+// HTTP client, regardless of the order in which options are given.
+func WithDebugLogging(w io.Writer) Option {
+	return func(o *options) {
+		o.debugOut = w
+	}
+}
+
+// WithTransport sets the transport that makes the requests, instead of
+// replacing the whole HTTP client with WithHTTPClient. Like the transport of a
+// client given to WithHTTPClient, it is wrapped, from the inside out, by
+// WithInsecureSkipVerify and WithTransportTuning, which only change an
+// *http.Transport, then by the debug and structured logging, and last by the
+// transport that sets the API keys, so that every request carries the keys
+// and the logging redacts them.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithTransportTuning configures the connection pool, i.e. to keep more
+// connections alive for a service that sends often. It is ignored for
+// transports that aren't an *http.Transport, see WithTransport for the order
+// in which transports are wrapped.
+func WithTransportTuning(tuning TransportTuning) Option {
+	return func(o *options) {
+		o.tuning = &tuning
+	}
+}
+
+// WithInsecureSkipVerify turns off verification of the server's TLS
+// certificate, i.e. for a Local API board on a LAN with a self-signed
+// certificate. This is unsafe, anyone on the network can then impersonate the
+// board and read the API key, so only use it for development. It is ignored
+// if WithHTTPClient is given a client with a transport that isn't an
+// *http.Transport.
+func WithInsecureSkipVerify() Option {
+	return func(o *options) {
+		o.insecure = true
+	}
+}
+
+// WithRetry retries requests that fail with 429 or a 5xx status up to a total
+// of maxAttempts attempts. The delay between attempts grows exponentially from
+// base, with jitter, unless the server asks for a delay with Retry-After.
+func WithRetry(maxAttempts int, base time.Duration) Option {
+	return func(o *options) {
+		o.retry = retryPolicy{
+			maxAttempts: maxAttempts,
+			base:        base,
+		}
+	}
+}
+
+// WithRateLimit limits how often messages are sent, calls block until the
+// limit allows the request or the context is done. Retries wait for the limit
+// as well. Reads aren't limited. The Read/Write and Subscription clients
+// default to DefaultRateLimit, use rate.Inf to disable limiting.
+func WithRateLimit(r rate.Limit, burst int) Option {
+	return func(o *options) {
+		o.rateLimit = r
+		o.rateBurst = burst
+	}
+}
+
+// WithSanitizeMode controls how SendText handles characters that can't be
+// shown on the board. The default is SanitizeStrict.
+func WithSanitizeMode(mode SanitizeMode, opts ...SanitizeOption) Option {
+	return func(o *options) {
+		o.sanitizeMode = mode
+		o.sanitizeOpts = opts
+	}
+}
+
+// WithObserver reports every request to o, i.e. to collect metrics.
+func WithObserver(o Observer) Option {
+	return func(opts *options) {
+		opts.observer = o
+	}
+}
+
+// WithTracer creates a client span for every request, covering its retries,
+// and propagates the trace context to the API with W3C trace context headers.
+func WithTracer(t trace.Tracer) Option {
+	return func(o *options) {
+		o.tracer = t
+	}
+}
+
+// WithAcceptContentTypes adds media types that are decoded as JSON, in addition
+// to application/json, i.e. for a proxy that labels JSON responses as
+// text/plain. Parameters such as charset are ignored when matching.
+func WithAcceptContentTypes(types ...string) Option {
+	return func(o *options) {
+		o.acceptContentTypes = append(o.acceptContentTypes, types...)
+	}
+}
+
+// WithDryRun renders messages to w instead of sending them. SendMessage,
+// SendText and WriteMessage succeed without making a request, responses carry
+// DryRunMessageID. Reads still go to the API.
+func WithDryRun(w io.Writer) Option {
+	return func(o *options) {
+		o.dryRun = w
+	}
+}
+
+// WithFailOnReadError makes SendIfChanged return an error when the current
+// layout can't be read, instead of sending unconditionally.
+func WithFailOnReadError() Option {
+	return func(o *options) {
+		o.failOnReadError = true
+	}
+}
+
+// WithClock replaces the clock used for timing, i.e. with a fake in tests.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithCompression gzips request bodies and asks the API for gzipped
+// responses, which are decompressed before MaxBodySize is applied. It is off
+// by default.
+func WithCompression(enabled bool) Option {
+	return func(o *options) {
+		o.compression = enabled
+	}
+}
+
+// WithQuietHours stops messages from being sent between the times of day of
+// start and end in loc, sends return ErrQuietHours instead. The dates of start
+// and end are ignored, a window like 22:00 to 07:00 spans midnight. If loc is
+// nil, the local time zone is used.
+func WithQuietHours(start, end time.Time, loc *time.Location) Option {
+	return func(o *options) {
+		o.quietStart = start
+		o.quietEnd = end
+		o.quietLoc = loc
+		o.quietSet = true
+	}
+}
+
+// WithQuietHoursQueue sends the last message that was held back during quiet
+// hours once they end. Errors of that send are dropped.
+func WithQuietHoursQueue() Option {
+	return func(o *options) {
+		o.quietQueue = true
+	}
+}
+
+// WithErrorBodyLimit sets how many bytes of a response body are included in
+// error messages, the default is DefaultErrorBodyLimit. APIError.Body always
+// holds the full body.
+func WithErrorBodyLimit(n int) Option {
+	return func(o *options) {
+		o.errorBodyLimit = n
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import "fmt"
+
+// PadLeft returns codes right aligned in a row of width cells, with blanks
+// added on the left.
+func PadLeft(codes []int, width int) ([]int, error) {
+	return pad(codes, width, AlignRight)
+}
+
+// PadRight returns codes left aligned in a row of width cells, with blanks
+// added on the right.
+func PadRight(codes []int, width int) ([]int, error) {
+	return pad(codes, width, AlignLeft)
+}
+
+// PadCenter returns codes centered in a row of width cells. If the blanks
+// can't be split evenly, the extra blank is on the right, as with Compose.
+func PadCenter(codes []int, width int) ([]int, error) {
+	return pad(codes, width, AlignCenter)
+}
+
+func pad(codes []int, width int, a Align) ([]int, error) {
+	if len(codes) > width {
+		return nil, fmt.Errorf("%d codes don't fit in a width of %d: %w", len(codes), width, ErrMessageTruncated)
+	}
+
+	start := 0
+	switch a {
+	case AlignRight:
+		start = width - len(codes)
+	case AlignCenter:
+		start = (width - len(codes)) / 2
+	}
+
+	row := make([]int, width)
+	for i := range row {
+		row[i] = CodeBlank
+	}
+	copy(row[start:], codes)
+	return row, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	t.Parallel()
+
+	codes := []int{1, 2, 3}
+	cases := []struct {
+		name  string
+		fn    func([]int, int) ([]int, error)
+		width int
+		want  []int
+	}{
+		{name: "left", fn: PadLeft, width: 5, want: []int{0, 0, 1, 2, 3}},
+		{name: "right", fn: PadRight, width: 5, want: []int{1, 2, 3, 0, 0}},
+		{name: "center_even", fn: PadCenter, width: 5, want: []int{0, 1, 2, 3, 0}},
+		{name: "center_odd", fn: PadCenter, width: 6, want: []int{0, 1, 2, 3, 0, 0}},
+		{name: "exact", fn: PadCenter, width: 3, want: []int{1, 2, 3}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.fn(codes, tc.width)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+
+	for _, fn := range []func([]int, int) ([]int, error){PadLeft, PadRight, PadCenter} {
+		if _, err := fn(codes, 2); !errors.Is(err, ErrMessageTruncated) {
+			t.Errorf("expected ErrMessageTruncated, got: %v", err)
+		}
+	}
+}
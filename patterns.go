@@ -0,0 +1,183 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidValue is returned by BarChart for values that aren't finite.
+var ErrInvalidValue = errors.New("invalid value")
+
+// checkColors validates the colors of a pattern.
+func checkColors(colors ...Color) error {
+	if len(colors) == 0 {
+		return errors.New("no colors given")
+	}
+	for _, c := range colors {
+		if !c.valid() {
+			return fmt.Errorf("%v: %w", c, ErrInvalidColor)
+		}
+	}
+	return nil
+}
+
+// Stripes returns a layout with one color per row, cycling through colors if
+// there are fewer than Rows.
+func Stripes(colors []Color) (Layout, error) {
+	var l Layout
+	if err := checkColors(colors...); err != nil {
+		return l, err
+	}
+	for x := range l {
+		for y := range l[x] {
+			l[x][y] = colors[x%len(colors)].Code()
+		}
+	}
+	return l, nil
+}
+
+// SolidColumns returns a layout with one color per column, cycling through
+// colors if there are fewer than Columns.
+func SolidColumns(colors []Color) (Layout, error) {
+	var l Layout
+	if err := checkColors(colors...); err != nil {
+		return l, err
+	}
+	for x := range l {
+		for y := range l[x] {
+			l[x][y] = colors[y%len(colors)].Code()
+		}
+	}
+	return l, nil
+}
+
+// Checkerboard returns a layout alternating between a and b, starting with a
+// in the top left corner.
+func Checkerboard(a, b Color) (Layout, error) {
+	var l Layout
+	if err := checkColors(a, b); err != nil {
+		return l, err
+	}
+	for x := range l {
+		for y := range l[x] {
+			c := a
+			if (x+y)%2 == 1 {
+				c = b
+			}
+			l[x][y] = c.Code()
+		}
+	}
+	return l, nil
+}
+
+// ProgressOption configures ProgressBar.
+type ProgressOption func(*progressOptions)
+
+type progressOptions struct {
+	label bool
+}
+
+// WithPercentLabel shows the percentage centered on the row below the bar, or
+// above it if the bar is on the last row.
+func WithPercentLabel() ProgressOption {
+	return func(o *progressOptions) {
+		o.label = true
+	}
+}
+
+// ProgressBar returns a layout with a bar across row that is filled from the
+// left for fraction of its width. Fraction is clamped to [0, 1].
+func ProgressBar(fraction float64, filled, empty Color, row int, opts ...ProgressOption) (Layout, error) {
+	var l Layout
+	if row < 0 || row >= Rows {
+		return l, fmt.Errorf("row %d is outside of the range 0-%d: %w", row, Rows-1, ErrInvalidCoordinate)
+	}
+	if err := checkColors(filled, empty); err != nil {
+		return l, err
+	}
+	o := &progressOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch {
+	case math.IsNaN(fraction) || fraction < 0:
+		fraction = 0
+	case fraction > 1:
+		fraction = 1
+	}
+	n := int(math.Round(fraction * Columns))
+	for y := range l[row] {
+		if y < n {
+			l[row][y] = filled.Code()
+		} else {
+			l[row][y] = empty.Code()
+		}
+	}
+
+	if o.label {
+		labelRow := row + 1
+		if labelRow == Rows {
+			labelRow = row - 1
+		}
+		text := fmt.Sprintf("%d%%", int(math.Round(fraction*100)))
+		if err := l.Print(labelRow, alignColumn(text, AlignCenter), text); err != nil {
+			return NewLayout(), err
+		}
+	}
+	return l, nil
+}
+
+// BarChart returns a layout with a vertical bar of color for each value, from
+// the left column, growing up from the bottom row. The bars are scaled so that
+// the largest value fills all rows, negative values have no bar. More than
+// Columns values return ErrMessageTruncated, NaN or infinite values return
+// ErrInvalidValue.
+func BarChart(values []float64, color Color) (Layout, error) {
+	var l Layout
+	if len(values) > Columns {
+		return l, fmt.Errorf("%d values, the maximum is %d: %w", len(values), Columns, ErrMessageTruncated)
+	}
+	if err := checkColors(color); err != nil {
+		return l, err
+	}
+
+	max := 0.0
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return l, fmt.Errorf("value %d is %v: %w", i, v, ErrInvalidValue)
+		}
+		if v > max {
+			max = v
+		}
+	}
+	if max == 0 {
+		return l, nil
+	}
+
+	for y, v := range values {
+		if v <= 0 {
+			continue
+		}
+		height := int(math.Round(v / max * Rows))
+		for x := Rows - height; x < Rows; x++ {
+			l[x][y] = color.Code()
+		}
+	}
+	return l, nil
+}
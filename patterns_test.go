@@ -0,0 +1,174 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPatterns(t *testing.T) {
+	t.Parallel()
+
+	stripes, err := Stripes([]Color{ColorRed, ColorBlue})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stripes[0][21] != int(ColorRed) || stripes[1][0] != int(ColorBlue) || stripes[4][7] != int(ColorRed) {
+		t.Errorf("wrong stripes:\n%v", stripes)
+	}
+
+	columns, err := SolidColumns([]Color{ColorGreen, ColorYellow, ColorWhite})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if columns[5][0] != int(ColorGreen) || columns[0][4] != int(ColorYellow) || columns[3][21] != int(ColorGreen) {
+		t.Errorf("wrong columns:\n%v", columns)
+	}
+
+	checks, err := Checkerboard(ColorBlack, ColorWhite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checks[0][0] != int(ColorBlack) || checks[0][1] != int(ColorWhite) || checks[1][0] != int(ColorWhite) || checks[5][21] != int(ColorBlack) {
+		t.Errorf("wrong checkerboard:\n%v", checks)
+	}
+
+	for _, l := range []Layout{stripes, columns, checks} {
+		if err := l.Validate(); err != nil {
+			t.Errorf("invalid pattern: %v", err)
+		}
+	}
+
+	if _, err := Stripes([]Color{ColorRed, Color(5)}); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor, got: %v", err)
+	}
+	if _, err := Checkerboard(Black, ColorRed); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor, got: %v", err)
+	}
+	if _, err := SolidColumns(nil); err == nil {
+		t.Errorf("expected an error without colors")
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		fraction float64
+		want     int
+	}{
+		{name: "empty", fraction: 0, want: 0},
+		{name: "half", fraction: 0.5, want: 11},
+		{name: "rounds", fraction: 0.3, want: 7},
+		{name: "full", fraction: 1, want: Columns},
+		{name: "below", fraction: -2, want: 0},
+		{name: "above", fraction: 1.5, want: Columns},
+		{name: "nan", fraction: math.NaN(), want: 0},
+	}
+	for _, tc := range cases {
+		l, err := ProgressBar(tc.fraction, ColorGreen, ColorWhite, 2)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		for y, code := range l[2] {
+			want := ColorWhite.Code()
+			if y < tc.want {
+				want = ColorGreen.Code()
+			}
+			if code != want {
+				t.Errorf("%s: column %d, want: %d, got: %d", tc.name, y, want, code)
+			}
+		}
+		for _, row := range []int{0, 1, 3, 4, 5} {
+			if l[row] != [Columns]int{} {
+				t.Errorf("%s: row %d should be blank", tc.name, row)
+			}
+		}
+	}
+
+	l, err := ProgressBar(0.42, ColorGreen, ColorBlack, 1, WithPercentLabel())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := rowText(l, 2), "         42%          "; got != want {
+		t.Errorf("wrong label, want: %q, got: %q", want, got)
+	}
+	// On the last row the label goes above the bar.
+	l, err = ProgressBar(1, ColorGreen, ColorBlack, Rows-1, WithPercentLabel())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := rowText(l, Rows-2), "         100%         "; got != want {
+		t.Errorf("wrong label, want: %q, got: %q", want, got)
+	}
+
+	if _, err := ProgressBar(0.5, ColorGreen, ColorWhite, Rows); !errors.Is(err, ErrInvalidCoordinate) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if _, err := ProgressBar(0.5, Color(3), ColorWhite, 0); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{6, 3, 0, -1, 1, -math.MaxFloat64, 5.5}
+	l, err := BarChart(values, ColorOrange)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	heights := []int{6, 3, 0, 0, 1, 0, 6}
+	for y := 0; y < Columns; y++ {
+		want := 0
+		if y < len(heights) {
+			want = heights[y]
+		}
+		for x := 0; x < Rows; x++ {
+			code := CodeBlank
+			if x >= Rows-want {
+				code = ColorOrange.Code()
+			}
+			if l[x][y] != code {
+				t.Errorf("row %d, column %d: want: %d, got: %d", x, y, code, l[x][y])
+			}
+		}
+	}
+
+	if l, err := BarChart([]float64{0, 0}, ColorOrange); err != nil || l != NewLayout() {
+		t.Errorf("expected a blank layout for zero values, got: %v, %v", l, err)
+	}
+
+	errCases := []struct {
+		name    string
+		values  []float64
+		color   Color
+		wantErr error
+	}{
+		{name: "too_many", values: make([]float64, Columns+1), color: ColorOrange, wantErr: ErrMessageTruncated},
+		{name: "bad_color", values: []float64{1}, color: Color(2), wantErr: ErrInvalidColor},
+		{name: "nan", values: []float64{1, math.NaN()}, color: ColorOrange, wantErr: ErrInvalidValue},
+		{name: "inf", values: []float64{1, math.Inf(1)}, color: ColorOrange, wantErr: ErrInvalidValue},
+		{name: "neg_inf", values: []float64{math.Inf(-1), 1}, color: ColorOrange, wantErr: ErrInvalidValue},
+	}
+	for _, tc := range errCases {
+		if _, err := BarChart(tc.values, tc.color); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: want error %v, got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
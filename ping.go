@@ -0,0 +1,65 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrUnauthorized is returned by Ping when the board rejects the API key.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrUnreachable is returned by Ping when the board can't be read for any
+	// other reason, i.e. a network error, a timeout or a server error.
+	ErrUnreachable = errors.New("board unreachable")
+)
+
+// pingError classifies the cause of a failed Ping, while errors.As still
+// reaches the cause, i.e. an *APIError.
+type pingError struct {
+	kind error
+	err  error
+}
+
+func (e *pingError) Error() string {
+	return e.kind.Error() + ": " + e.err.Error()
+}
+
+func (e *pingError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *pingError) Unwrap() error {
+	return e.err
+}
+
+// Ping checks that the board can be reached and accepts the API key, without
+// changing what it shows, i.e. for a readiness probe. It reads the current
+// message and returns nil on success, an error matching ErrUnauthorized if the
+// key was rejected and an error matching ErrUnreachable otherwise.
+func (c *RWClient) Ping(ctx context.Context) error {
+	_, err := c.ReadMessage(ctx)
+	if err == nil || errors.Is(err, ErrNoCurrentMessage) {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.IsUnauthorized() || apiErr.StatusCode == http.StatusForbidden) {
+		return &pingError{kind: ErrUnauthorized, err: err}
+	}
+	return &pingError{kind: ErrUnreachable, err: err}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := json.Marshal(NewLayout())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blankLayout := string(encoded)
+
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "ok", status: http.StatusOK, body: `{"currentMessage":{"layout":"` + blankLayout + `"}}`},
+		{name: "no_message", status: http.StatusOK, body: `{}`},
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: ErrUnauthorized},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: ErrUnauthorized},
+		{name: "server_error", status: http.StatusBadGateway, wantErr: ErrUnreachable},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Ping must only read, got: %v", r.Method)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+			err := c.Ping(context.Background())
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got: %v", tc.wantErr, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.status {
+				t.Errorf("the API error should be kept, got: %v", err)
+			}
+		})
+	}
+
+	t.Run("connection_refused", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		err := NewRWClient("rw-key", WithBaseURL(srv.URL)).Ping(context.Background())
+		if !errors.Is(err, ErrUnreachable) || errors.Is(err, ErrUnauthorized) {
+			t.Errorf("want error %v, got: %v", ErrUnreachable, err)
+		}
+	})
+}
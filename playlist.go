@@ -0,0 +1,93 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrEmptyPlaylist is returned by Playlist.Run when a cycle has no entries.
+var ErrEmptyPlaylist = errors.New("empty playlist")
+
+type playlistEntry struct {
+	layout   Layout
+	duration time.Duration
+}
+
+// Playlist cycles through layouts, showing each for its own duration, until
+// it is stopped. It is safe to change the entries while it runs, changes are
+// picked up at the start of the next cycle.
+type Playlist struct {
+	client *RWClient
+
+	mu      sync.Mutex
+	entries []playlistEntry
+}
+
+// NewPlaylist returns an empty Playlist that sends through c.
+func NewPlaylist(c *RWClient) *Playlist {
+	return &Playlist{client: c}
+}
+
+// Add appends l to the playlist, it is shown for d before the next entry.
+func (p *Playlist) Add(l Layout, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = append(p.entries, playlistEntry{layout: l, duration: d})
+}
+
+// Clear removes all entries. A running playlist finishes the current cycle
+// and then returns ErrEmptyPlaylist.
+func (p *Playlist) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = nil
+}
+
+// Len returns the number of entries.
+func (p *Playlist) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+// Run sends the entries in order and starts over after the last one, until
+// ctx is done, which returns the context's error. A failed send stops the
+// playlist and returns the error.
+func (p *Playlist) Run(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		entries := make([]playlistEntry, len(p.entries))
+		copy(entries, p.entries)
+		p.mu.Unlock()
+
+		if len(entries) == 0 {
+			return ErrEmptyPlaylist
+		}
+		for _, e := range entries {
+			if _, err := p.client.SendMessage(ctx, e.layout); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-p.client.clock.After(e.duration):
+			}
+		}
+	}
+}
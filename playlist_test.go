@@ -0,0 +1,100 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPlaylist(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	layouts := make([]Layout, 3)
+	for i := range layouts {
+		layouts[i][0][0] = i + 1
+	}
+
+	var p *Playlist
+	var mu sync.Mutex
+	var posted []int
+	clock := &fakeClock{}
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var l Layout
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		mu.Lock()
+		posted = append(posted, l[0][0])
+		n := len(posted)
+		mu.Unlock()
+
+		switch n {
+		case 1:
+			// Picked up on the next cycle.
+			p.Add(layouts[2], 3*time.Second)
+		case 5:
+			cancel()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	}, WithClock(clock))
+
+	p = NewPlaylist(c)
+	p.Add(layouts[0], time.Second)
+	p.Add(layouts[1], 2*time.Second)
+
+	if err := p.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want error %v, got: %v", context.Canceled, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []int{1, 2, 1, 2, 3}; !reflect.DeepEqual(posted, want) {
+		t.Errorf("wrong sends, want: %v, got: %v", want, posted)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second, time.Second, 2 * time.Second}; !reflect.DeepEqual(clock.Delays()[:4], want) {
+		t.Errorf("wrong delays, want: %v, got: %v", want, clock.Delays())
+	}
+}
+
+func TestPlaylistEmpty(t *testing.T) {
+	t.Parallel()
+
+	p := NewPlaylist(newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request")
+	}))
+	if err := p.Run(context.Background()); !errors.Is(err, ErrEmptyPlaylist) {
+		t.Errorf("want error %v, got: %v", ErrEmptyPlaylist, err)
+	}
+
+	p.Add(NewLayout(), time.Second)
+	if p.Len() != 1 {
+		t.Errorf("want 1 entry, got %d", p.Len())
+	}
+	p.Clear()
+	if err := p.Run(context.Background()); !errors.Is(err, ErrEmptyPlaylist) {
+		t.Errorf("want error %v, got: %v", ErrEmptyPlaylist, err)
+	}
+}
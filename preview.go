@@ -0,0 +1,82 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"io"
+	"strings"
+)
+
+// ansiColors are the ANSI background colors used to show color tiles.
+var ansiColors = map[Color]string{
+	ColorRed:    "\x1b[48;2;218;41;28m",
+	ColorOrange: "\x1b[48;2;255;117;0m",
+	ColorYellow: "\x1b[48;2;255;184;28m",
+	ColorGreen:  "\x1b[48;2;0;154;68m",
+	ColorBlue:   "\x1b[48;2;0;133;202m",
+	ColorViolet: "\x1b[48;2;112;47;138m",
+	ColorWhite:  "\x1b[48;2;255;255;255m",
+	ColorBlack:  "\x1b[48;2;0;0;0m",
+	ColorFilled: "\x1b[48;2;255;255;255m",
+}
+
+const ansiReset = "\x1b[0m"
+
+// String renders the layout as a framed grid for a terminal. Text cells are
+// shown as their character, color tiles as a cell with an ANSI background.
+func (l Layout) String() string {
+	var b strings.Builder
+	l.render(&b, func(b *strings.Builder, code int) {
+		if r, ok := DecodeCode(code); ok {
+			b.WriteRune(r)
+			return
+		}
+		if ansi, ok := ansiColors[Color(code)]; ok {
+			b.WriteString(ansi + " " + ansiReset)
+			return
+		}
+		b.WriteRune(colorTileRune)
+	})
+	return b.String()
+}
+
+// RenderPlain writes the layout as a framed grid without escape codes, i.e.
+// for logs. Color tiles are shown as a block character.
+func (l Layout) RenderPlain(w io.Writer) error {
+	var b strings.Builder
+	l.render(&b, func(b *strings.Builder, code int) {
+		r, ok := DecodeCode(code)
+		if !ok {
+			r = colorTileRune
+		}
+		b.WriteRune(r)
+	})
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// render writes the frame of the grid, cell writes each cell.
+func (l Layout) render(b *strings.Builder, cell func(*strings.Builder, int)) {
+	border := "+" + strings.Repeat("-", Columns) + "+\n"
+	b.WriteString(border)
+	for _, row := range l {
+		b.WriteByte('|')
+		for _, code := range row {
+			cell(b, code)
+		}
+		b.WriteString("|\n")
+	}
+	b.WriteString(border)
+}
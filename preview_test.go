@@ -0,0 +1,52 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLayoutRender(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	l.Print(1, 0, "DEBUG")
+	l.SetColor(1, 5, ColorRed)
+
+	var b bytes.Buffer
+	if err := l.RenderPlain(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plain := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(plain) != Rows+2 {
+		t.Fatalf("expected %d lines, got %d", Rows+2, len(plain))
+	}
+	if want := "|DEBUG█" + strings.Repeat(" ", Columns-6) + "|"; plain[2] != want {
+		t.Errorf("wrong row, want: %q, got: %q", want, plain[2])
+	}
+	if strings.Contains(b.String(), "\x1b") {
+		t.Errorf("plain rendering contains escape codes")
+	}
+
+	s := l.String()
+	if !strings.Contains(s, "|DEBUG"+ansiColors[ColorRed]+" "+ansiReset) {
+		t.Errorf("expected an ANSI color tile, got:\n%q", s)
+	}
+	if strings.ContainsRune(s, colorTileRune) {
+		t.Errorf("expected no block characters, got:\n%s", s)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuietHours is returned by sends during the window set with
+// WithQuietHours, whether or not the message is queued.
+var ErrQuietHours = errors.New("message not sent during quiet hours")
+
+// quietHours is a daily window in which messages aren't sent.
+type quietHours struct {
+	start, end time.Duration // offsets from midnight
+	loc        *time.Location
+	queue      bool
+
+	mu      sync.Mutex
+	pending func(context.Context) error
+	waiting bool
+}
+
+func newQuietHours(start, end time.Time, loc *time.Location, queue bool) *quietHours {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &quietHours{
+		start: sinceMidnight(start),
+		end:   sinceMidnight(end),
+		loc:   loc,
+		queue: queue,
+	}
+}
+
+// sinceMidnight returns the time of day of t as an offset from midnight.
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
+
+// contains returns true if t is inside the window. The window includes its
+// start and excludes its end, a window that ends before it starts wraps
+// around midnight.
+func (q *quietHours) contains(t time.Time) bool {
+	d := sinceMidnight(t.In(q.loc))
+	switch {
+	case q.start < q.end:
+		return d >= q.start && d < q.end
+	case q.start > q.end:
+		return d >= q.start || d < q.end
+	default:
+		return false
+	}
+}
+
+// untilEnd returns how long it is from t until the window ends next.
+func (q *quietHours) untilEnd(t time.Time) time.Duration {
+	t = t.In(q.loc)
+	y, m, d := t.Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, q.loc).Add(q.end)
+	if !end.After(t) {
+		end = time.Date(y, m, d+1, 0, 0, 0, 0, q.loc).Add(q.end)
+	}
+	return end.Sub(t)
+}
+
+// hold queues send to run once the window ends, replacing any message that
+// was queued before.
+func (q *quietHours) hold(clock Clock, send func(context.Context) error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = send
+	if q.waiting {
+		return
+	}
+	q.waiting = true
+	delay := q.untilEnd(clock.Now())
+	go func() {
+		<-clock.After(delay)
+
+		q.mu.Lock()
+		send := q.pending
+		q.pending = nil
+		q.waiting = false
+		q.mu.Unlock()
+
+		// There is no caller to report the error to.
+		_ = send(context.Background())
+	}()
+}
+
+// checkQuietHours returns ErrQuietHours if messages aren't sent right now. If
+// queuing is enabled, send is run when quiet hours end.
+func (c *baseClient) checkQuietHours(send func(context.Context) error) error {
+	if c.quiet == nil || !c.quiet.contains(c.clock.Now()) {
+		return nil
+	}
+	if c.quiet.queue {
+		c.quiet.hold(c.clock, send)
+	}
+	return ErrQuietHours
+}
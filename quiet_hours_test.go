@@ -0,0 +1,134 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("test", -5*60*60)
+	at := func(h, m int) time.Time {
+		return time.Date(2021, 3, 6, h, m, 0, 0, loc)
+	}
+	night := newQuietHours(at(22, 0), at(7, 0), loc, false)
+	afternoon := newQuietHours(at(13, 0), at(14, 30), loc, false)
+
+	cases := []struct {
+		name string
+		q    *quietHours
+		t    time.Time
+		want bool
+	}{
+		{name: "night_start", q: night, t: at(22, 0), want: true},
+		{name: "night_midnight", q: night, t: at(0, 0), want: true},
+		{name: "night_morning", q: night, t: at(6, 59), want: true},
+		{name: "night_end", q: night, t: at(7, 0), want: false},
+		{name: "night_day", q: night, t: at(12, 0), want: false},
+		{name: "night_other_zone", q: night, t: time.Date(2021, 3, 6, 4, 0, 0, 0, time.UTC), want: true},
+		{name: "afternoon_inside", q: afternoon, t: at(14, 0), want: true},
+		{name: "afternoon_before", q: afternoon, t: at(12, 59), want: false},
+		{name: "afternoon_after", q: afternoon, t: at(14, 30), want: false},
+	}
+	for _, tc := range cases {
+		if got := tc.q.contains(tc.t); got != tc.want {
+			t.Errorf("%s: want: %v, got: %v", tc.name, tc.want, got)
+		}
+	}
+
+	if got := night.untilEnd(at(23, 0)); got != 8*time.Hour {
+		t.Errorf("wrong time until the end of the window: %v", got)
+	}
+	if got := night.untilEnd(at(1, 30)); got != 5*time.Hour+30*time.Minute {
+		t.Errorf("wrong time until the end of the window: %v", got)
+	}
+}
+
+func TestQuietHoursSend(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC)
+	end := time.Date(0, 1, 1, 7, 0, 0, 0, time.UTC)
+	night := time.Date(2021, 3, 6, 23, 0, 0, 0, time.UTC)
+
+	t.Run("dropped", func(t *testing.T) {
+		t.Parallel()
+
+		c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request during quiet hours")
+		}, WithQuietHours(start, end, time.UTC), WithClock(&fakeClock{now: night}))
+		if _, err := c.SendText(context.Background(), "SHH"); !errors.Is(err, ErrQuietHours) {
+			t.Errorf("expected ErrQuietHours, got: %v", err)
+		}
+		if _, err := c.SendMessage(context.Background(), NewLayout()); !errors.Is(err, ErrQuietHours) {
+			t.Errorf("expected ErrQuietHours, got: %v", err)
+		}
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		t.Parallel()
+
+		want := NewLayout()
+		want.Print(0, 0, "GOOD MORNING")
+		clock := &fakeClock{now: night}
+		h := &boardHandler{t: t}
+		c := newRWTestClient(t, h.ServeHTTP, WithQuietHours(start, end, time.UTC), WithQuietHoursQueue(), WithClock(clock))
+
+		if _, err := c.SendMessage(context.Background(), want); !errors.Is(err, ErrQuietHours) {
+			t.Fatalf("expected ErrQuietHours, got: %v", err)
+		}
+		if posted := waitPosted(t, h, 1); posted[0] != want {
+			t.Errorf("wrong layout sent after quiet hours: %v", posted[0])
+		}
+		if d := clock.Delays(); len(d) != 1 || d[0] != 8*time.Hour {
+			t.Errorf("expected to wait until the end of quiet hours, got: %v", d)
+		}
+	})
+	t.Run("queued_text_options", func(t *testing.T) {
+		t.Parallel()
+
+		posted := make(chan string, 2)
+		c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var msg TextMessage
+			if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+			posted <- msg.Text
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		}, WithQuietHours(start, end, time.UTC), WithQuietHoursQueue(), WithClock(&fakeClock{now: night}))
+
+		// The client is strict, the queued send must keep the per-call mode.
+		_, err := c.SendTextOpts(context.Background(), "ci 🟢", WithTextSanitizeMode(SanitizeDrop, WithEmojiPassthrough()))
+		if !errors.Is(err, ErrQuietHours) {
+			t.Fatalf("expected ErrQuietHours, got: %v", err)
+		}
+		select {
+		case got := <-posted:
+			if want := "CI 🟢"; got != want {
+				t.Errorf("wrong text sent after quiet hours, want: %q, got: %q", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("queued text was never sent")
+		}
+	})
+}
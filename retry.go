@@ -0,0 +1,102 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// maxBackoff caps the computed delay between retries.
+const maxBackoff = time.Minute
+
+type retryPolicy struct {
+	maxAttempts int
+	base        time.Duration
+}
+
+// backoff returns the jittered delay before the given retry, starting at 0.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	d := p.base
+	for i := 0; i < retry && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Wait between half and the full delay.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retryable returns true for errors that are worth another attempt.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.IsRateLimited() || apiErr.IsServerError()
+}
+
+func (c *baseClient) doRetry(req *http.Request, out interface{}) (*http.Response, error) {
+	ctx := req.Context()
+
+	var lastErr error
+	for attempt := 0; attempt < c.retry.maxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := c.retry.backoff(attempt - 1)
+			var apiErr *APIError
+			if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+				delay = apiErr.RetryAfter
+			}
+			c.observer.ObserveRetry(attempt, delay, lastErr)
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-c.clock.After(delay):
+			}
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				// The body was consumed and can't be sent again.
+				return nil, lastErr
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := c.doOnce(attemptReq, out)
+		if err == nil {
+			return resp, nil
+		}
+		if !retryable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
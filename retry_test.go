@@ -0,0 +1,129 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// statusSequence returns a handler that responds with the given status codes in
+// order and then with 200.
+func statusSequence(t *testing.T, calls *int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		if body, _ := io.ReadAll(r.Body); len(body) == 0 {
+			t.Errorf("attempt %d had an empty body", n)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+		wantCode  int
+	}{
+		{name: "success_after_retries", statuses: []int{503, 429}, wantCalls: 3},
+		{name: "gives_up", statuses: []int{502, 502, 502, 502}, wantCalls: 3, wantCode: 502},
+		{name: "unauthorized_fails_fast", statuses: []int{401}, wantCalls: 1, wantCode: 401},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			srv := httptest.NewServer(statusSequence(t, &calls, tc.statuses...))
+			defer srv.Close()
+
+			c := NewRWClient("key", WithBaseURL(srv.URL), WithRetry(3, time.Millisecond), WithRateLimit(rate.Inf, 0))
+			_, err := c.SendText(context.Background(), "RETRY")
+
+			if got := atomic.LoadInt32(&calls); got != tc.wantCalls {
+				t.Errorf("wrong number of calls, want: %d, got: %d", tc.wantCalls, got)
+			}
+			if tc.wantCode == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.wantCode {
+				t.Errorf("wrong error, want status %d, got: %v", tc.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestRetryContextCancel(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := httptest.NewServer(statusSequence(t, &calls, 503, 503, 503))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewRWClient("key", WithBaseURL(srv.URL), WithRetry(5, time.Hour), WithRateLimit(rate.Inf, 0))
+	start := time.Now()
+	_, err := c.SendText(ctx, "CANCEL")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("retry loop didn't abort, took %v", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Mon, 01 Mar 2021 12:00:30 GMT": 30 * time.Second,
+		"Mon, 01 Mar 2021 11:00:00 GMT": 0,
+	}
+	for in, want := range cases {
+		if got := parseRetryAfter(in, now); got != want {
+			t.Errorf("parseRetryAfter(%q), want: %v, got: %v", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	RWAPIKeyHeader = "X-Vestaboard-Read-Write-Key"
+
+	defaultRWBaseURL = "https://rw.vestaboard.com"
+)
+
+var (
+	ErrNoCurrentMessage = errors.New("board has no current message")
+	ErrNoChange         = errors.New("layout is already displayed")
+	ErrMessageRejected  = errors.New("message rejected")
+	ErrWrongEndpoint    = errors.New("wrong API for the key")
+)
+
+// rwErrorStatuses are the statuses of a 200 response that mean the message
+// wasn't accepted. Other statuses are passed through in the response.
+var rwErrorStatuses = map[string]bool{
+	"error":    true,
+	"failed":   true,
+	"failure":  true,
+	"invalid":  true,
+	"rejected": true,
+}
+
+// RWClient is a client for the Vestaboard Read/Write API, which controls a
+// single board with a read/write key.
+type RWClient struct {
+	baseClient
+
+	// endpointErr is returned by every call if the base URL is known not to
+	// accept a Read/Write key.
+	endpointErr error
+}
+
+// NewRWClient creates a client for the Read/Write API. Options are applied in
+// order, if none are given the production API is used and requests without a
+// context deadline time out after DefaultTimeout. If the base URL is the
+// Subscription API, every call returns ErrWrongEndpoint.
+func NewRWClient(rwKey string, opts ...Option) *RWClient {
+	c := &RWClient{
+		baseClient: newBaseClient(options{
+			baseURL:   defaultRWBaseURL,
+			keys:      map[string]string{RWAPIKeyHeader: rwKey},
+			rateLimit: DefaultRateLimit,
+			rateBurst: 1,
+		}, opts),
+	}
+	c.endpointErr = checkRWBaseURL(c.baseURL)
+	return c
+}
+
+// checkRWBaseURL returns an ErrWrongEndpoint error if baseURL is the
+// Subscription API, which rejects Read/Write keys with confusing errors.
+func checkRWBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		// The request itself reports the invalid URL.
+		return nil
+	}
+	platform, _ := url.Parse(defaultBaseURL)
+	if !strings.EqualFold(u.Hostname(), platform.Hostname()) && !strings.HasPrefix(u.Path, subscriptionsPath) {
+		return nil
+	}
+	return fmt.Errorf("%s is the Subscription API, which takes an API key and secret, "+
+		"a Read/Write key only works with the Read/Write API at %s: %w", baseURL, defaultRWBaseURL, ErrWrongEndpoint)
+}
+
+// RWMessageResponse is the response of the Read/Write API to a posted message.
+type RWMessageResponse struct {
+	Status  string `json:"status"`
+	ID      string `json:"id"`
+	Created int    `json:"created"`
+}
+
+type rwCurrentMessage struct {
+	ID string `json:"id"`
+	// Layout is usually a string containing the JSON encoded character codes.
+	Layout json.RawMessage `json:"layout"`
+}
+
+type rwReadResponse struct {
+	CurrentMessage *rwCurrentMessage `json:"currentMessage"`
+}
+
+// SendMessage posts a layout to the board. The returned message's Text field
+// carries the status reported by the API, MessageID and CreatedAt identify the
+// message in the board's history. A status that reports a failure, even with a
+// 200 response, returns ErrMessageRejected.
+func (c *RWClient) SendMessage(ctx context.Context, l Layout) (*MessageResponse, error) {
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+	if err := c.checkQuietHours(func(ctx context.Context) error {
+		_, err := c.SendMessage(ctx, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if c.dryRun != nil {
+		return c.dryRunMessage(l)
+	}
+
+	b, err := encodeLayoutRequest(l, 0)
+	if err != nil {
+		return nil, err
+	}
+	return c.post(ctx, b)
+}
+
+// SendIfChanged posts l only if it differs from what the board currently
+// shows, otherwise ErrNoChange is returned and nothing is sent. If the board
+// can't be read, the layout is sent anyway, unless the client was created with
+// WithFailOnReadError.
+func (c *RWClient) SendIfChanged(ctx context.Context, l Layout) (*MessageResponse, error) {
+	current, err := c.ReadMessage(ctx)
+	switch {
+	case err == nil:
+		if current.Equal(l) {
+			return nil, ErrNoChange
+		}
+	case errors.Is(err, ErrNoCurrentMessage):
+	case c.failOnReadError:
+		return nil, fmt.Errorf("failed to read current layout: %w", err)
+	}
+	return c.SendMessage(ctx, l)
+}
+
+// SendCodes posts a 6x22 array of character codes to the board. The dimensions
+// and codes are validated first.
+func (c *RWClient) SendCodes(ctx context.Context, codes [][]int) (*MessageResponse, error) {
+	l, err := layoutFromCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendMessage(ctx, l)
+}
+
+// SendText posts a message to the board with the default formatting. Empty or
+// whitespace only text returns ErrEmptyMessage, use Clear to blank the board.
+func (c *RWClient) SendText(ctx context.Context, text string) (*MessageResponse, error) {
+	return c.SendTextOpts(ctx, text)
+}
+
+// SendTextOpts is SendText with options that control how the text is checked
+// before it is sent.
+func (c *RWClient) SendTextOpts(ctx context.Context, text string, opts ...TextOption) (*MessageResponse, error) {
+	text, err := c.prepareText(text, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// The queued send posts the prepared text, so that opts still apply.
+	if err := c.checkQuietHours(func(ctx context.Context) error {
+		_, err := c.postText(ctx, text)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return c.postText(ctx, text)
+}
+
+// postText posts text that prepareText has already checked.
+func (c *RWClient) postText(ctx context.Context, text string) (*MessageResponse, error) {
+	if c.dryRun != nil {
+		return c.dryRunText(text)
+	}
+
+	var b bytes.Buffer
+	body := &TextMessage{
+		Text: text,
+	}
+	if err := json.NewEncoder(&b).Encode(body); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return c.post(ctx, &b)
+}
+
+// Clear blanks the board.
+func (c *RWClient) Clear(ctx context.Context) (*MessageResponse, error) {
+	return c.SendMessage(ctx, solid(CodeBlank))
+}
+
+// Fill sets every cell of the board to a single color.
+func (c *RWClient) Fill(ctx context.Context, color Color) (*MessageResponse, error) {
+	if !color.valid() {
+		return nil, fmt.Errorf("%v: %w", color, ErrInvalidColor)
+	}
+	return c.SendMessage(ctx, solid(color.Code()))
+}
+
+// DoLayout posts a layout and returns the raw response and body without
+// decoding it, i.e. to read response headers. The response body is already
+// closed. Non-2xx responses return an *APIError, like SendMessage does.
+// Unlike SendMessage, DoLayout always makes a request, even in dry run mode.
+func (c *RWClient) DoLayout(ctx context.Context, l Layout) (*http.Response, []byte, error) {
+	if err := l.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid layout: %w", err)
+	}
+	b, err := encodeLayoutRequest(l, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := c.newPost(ctx, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body []byte
+	resp, err := c.do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// newPost creates a request that posts b to the board.
+func (c *RWClient) newPost(ctx context.Context, b *bytes.Buffer) (*http.Request, error) {
+	if c.endpointErr != nil {
+		return nil, c.endpointErr
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/", b)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func (c *RWClient) post(ctx context.Context, b *bytes.Buffer) (*MessageResponse, error) {
+	req, err := c.newPost(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var rwResponse RWMessageResponse
+	if _, err := c.do(req, &rwResponse); err != nil {
+		return nil, err
+	}
+	if rwErrorStatuses[strings.ToLower(strings.TrimSpace(rwResponse.Status))] {
+		return nil, fmt.Errorf("status %q: %w", rwResponse.Status, ErrMessageRejected)
+	}
+
+	response := &MessageResponse{
+		Message: Message{
+			ID:      rwResponse.ID,
+			Created: rwResponse.Created,
+			Text:    rwResponse.Status,
+		},
+	}
+	response.setMetadata()
+	return response, nil
+}
+
+// ReadMessage returns the layout that is currently shown on the board. If the
+// board has never been written to, ErrNoCurrentMessage is returned.
+func (c *RWClient) ReadMessage(ctx context.Context) (*Layout, error) {
+	if c.endpointErr != nil {
+		return nil, c.endpointErr
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var response rwReadResponse
+	if _, err := c.do(req, &response); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, ErrNoCurrentMessage
+		}
+		return nil, err
+	}
+	if response.CurrentMessage == nil || len(response.CurrentMessage.Layout) == 0 {
+		return nil, ErrNoCurrentMessage
+	}
+
+	raw := []byte(response.CurrentMessage.Layout)
+	// The layout is returned as a JSON string containing the array of codes.
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("failed to decode layout: %w", err)
+		}
+		if s == "" {
+			return nil, ErrNoCurrentMessage
+		}
+		raw = []byte(s)
+	}
+	if string(raw) == "null" {
+		return nil, ErrNoCurrentMessage
+	}
+
+	var l Layout
+	if err := json.Unmarshal(raw, &l); err != nil {
+		return nil, fmt.Errorf("failed to decode layout: %w", err)
+	}
+	return &l, nil
+}
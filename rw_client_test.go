@@ -0,0 +1,479 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func newRWTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) *RWClient {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	opts = append([]Option{
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithRateLimit(rate.Inf, 0),
+	}, opts...)
+	return NewRWClient("rw-key", opts...)
+}
+
+func TestRWSendMessage(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "HELLO")
+
+	var got Layout
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if k := r.Header.Get(RWAPIKeyHeader); k != "rw-key" {
+			t.Errorf("wrong key header: %q", k)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","id":"msg-id","created":1615000000000}`))
+	})
+
+	resp, err := c.SendMessage(context.Background(), want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("wrong layout sent, want: %v, got: %v", want, got)
+	}
+	if resp.ID != "msg-id" || resp.Created != 1615000000000 || resp.Text != "ok" {
+		t.Errorf("wrong response: %+v", resp)
+	}
+	if resp.MessageID != "msg-id" {
+		t.Errorf("wrong message ID: %q", resp.MessageID)
+	}
+	if want := time.Date(2021, 3, 6, 3, 6, 40, 0, time.UTC); !resp.CreatedAt.Equal(want) {
+		t.Errorf("wrong created time, want: %v, got: %v", want, resp.CreatedAt)
+	}
+}
+
+func TestRWSendMessageStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status  string
+		wantErr error
+	}{
+		{status: "ok"},
+		{status: "queued"},
+		{status: ""},
+		{status: "error", wantErr: ErrMessageRejected},
+		{status: "Failed", wantErr: ErrMessageRejected},
+		{status: "invalid", wantErr: ErrMessageRejected},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.status, func(t *testing.T) {
+			t.Parallel()
+
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]string{"status": tc.status, "id": "msg-id"})
+			})
+
+			resp, err := c.SendText(context.Background(), "hi")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tc.status) {
+					t.Errorf("error doesn't include the status: %v", err)
+				}
+				return
+			}
+			// Statuses that aren't known to be errors are passed through.
+			if resp.Text != tc.status {
+				t.Errorf("wrong status, want: %q, got: %q", tc.status, resp.Text)
+			}
+		})
+	}
+}
+
+func TestRWWrongEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		baseURL string
+		wantErr error
+	}{
+		{baseURL: defaultBaseURL, wantErr: ErrWrongEndpoint},
+		{baseURL: "https://PLATFORM.vestaboard.com/", wantErr: ErrWrongEndpoint},
+		{baseURL: "http://localhost:8080/subscriptions", wantErr: ErrWrongEndpoint},
+		{baseURL: defaultRWBaseURL},
+		{baseURL: "http://localhost:8080"},
+	}
+	for _, tc := range cases {
+		c := NewRWClient("rw-key", WithBaseURL(tc.baseURL))
+		if !errors.Is(c.endpointErr, tc.wantErr) {
+			t.Errorf("%s: wrong error, want: %v, got: %v", tc.baseURL, tc.wantErr, c.endpointErr)
+		}
+	}
+
+	// The error is returned before a request is made.
+	c := NewRWClient("rw-key", WithBaseURL(defaultBaseURL), WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", r.URL)
+			return nil, errors.New("unexpected request")
+		}),
+	}))
+	if _, err := c.SendText(context.Background(), "hi"); !errors.Is(err, ErrWrongEndpoint) {
+		t.Errorf("wrong error: %v", err)
+	} else if !strings.Contains(err.Error(), "API key and secret") {
+		t.Errorf("error doesn't explain the auth models: %v", err)
+	}
+	if _, err := c.ReadMessage(context.Background()); !errors.Is(err, ErrWrongEndpoint) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestRWReadMessage(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(2, 3, "CURRENT")
+	encoded, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoted, err := json.Marshal(string(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{
+			name:   "string_layout",
+			status: http.StatusOK,
+			body:   `{"currentMessage":{"id":"x","layout":` + string(quoted) + `}}`,
+		},
+		{
+			name:   "array_layout",
+			status: http.StatusOK,
+			body:   `{"currentMessage":{"id":"x","layout":` + string(encoded) + `}}`,
+		},
+		{
+			name:    "never_written",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: ErrNoCurrentMessage,
+		},
+		{
+			name:    "not_found",
+			status:  http.StatusNotFound,
+			body:    `{}`,
+			wantErr: ErrNoCurrentMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("wrong method: %v", r.Method)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+
+			got, err := c.ReadMessage(context.Background())
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != want {
+				t.Errorf("wrong layout, want: %v, got: %v", want, *got)
+			}
+		})
+	}
+}
+
+func TestRWRateLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewRWClient("rw-key", WithBaseURL(srv.URL), WithRateLimit(rate.Every(100*time.Millisecond), 1))
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := c.SendText(ctx, "LIMITED"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Errorf("second send wasn't limited, took %v", d)
+	}
+
+	// A send that has to wait longer than the context allows fails.
+	c = NewRWClient("rw-key", WithBaseURL(srv.URL), WithRateLimit(rate.Every(time.Hour), 1))
+	if _, err := c.SendText(ctx, "FIRST"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.SendText(shortCtx, "SECOND"); err == nil {
+		t.Errorf("expected rate limit error, got nil")
+	}
+}
+
+func TestRWClearFill(t *testing.T) {
+	t.Parallel()
+
+	var got Layout
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	ctx := context.Background()
+	if _, err := c.Fill(ctx, ColorGreen); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := range got {
+		for y := range got[x] {
+			if got[x][y] != int(ColorGreen) {
+				t.Fatalf("cell %d,%d not filled: %d", x, y, got[x][y])
+			}
+		}
+	}
+
+	if _, err := c.Clear(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != NewLayout() {
+		t.Errorf("board not cleared: %v", got)
+	}
+
+	if _, err := c.Fill(ctx, Color(12)); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestRWSendCodes(t *testing.T) {
+	t.Parallel()
+
+	var got Layout
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	codes := make([][]int, Rows)
+	for x := range codes {
+		codes[x] = make([]int, Columns)
+	}
+	codes[1][2] = 8
+
+	ctx := context.Background()
+	if _, err := c.SendCodes(ctx, codes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[1][2] != 8 {
+		t.Errorf("wrong layout sent: %v", got)
+	}
+
+	codes[3] = codes[3][:21]
+	if _, err := c.SendCodes(ctx, codes); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
+func TestRWSendIfChanged(t *testing.T) {
+	t.Parallel()
+
+	shown := NewLayout()
+	shown.Print(0, 0, "SHOWN")
+	other := NewLayout()
+	other.Print(0, 0, "OTHER")
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		h := &boardHandler{t: t, current: &shown}
+		c := newRWTestClient(t, h.ServeHTTP)
+		if _, err := c.SendIfChanged(context.Background(), shown); !errors.Is(err, ErrNoChange) {
+			t.Fatalf("expected ErrNoChange, got: %v", err)
+		}
+		if n := len(h.Posted()); n != 0 {
+			t.Errorf("expected nothing to be sent, got %d messages", n)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		t.Parallel()
+
+		h := &boardHandler{t: t, current: &shown}
+		c := newRWTestClient(t, h.ServeHTTP)
+		if _, err := c.SendIfChanged(context.Background(), other); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if posted := h.Posted(); len(posted) != 1 || posted[0] != other {
+			t.Errorf("expected the new layout to be sent, got: %v", posted)
+		}
+	})
+
+	readFails := func(posts *int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			*posts++
+			w.Write([]byte(`{"status":"ok"}`))
+		}
+	}
+
+	t.Run("read_error", func(t *testing.T) {
+		t.Parallel()
+
+		var posts int
+		c := newRWTestClient(t, readFails(&posts))
+		if _, err := c.SendIfChanged(context.Background(), other); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if posts != 1 {
+			t.Errorf("expected the layout to be sent, got %d posts", posts)
+		}
+	})
+
+	t.Run("fail_on_read_error", func(t *testing.T) {
+		t.Parallel()
+
+		var posts int
+		c := newRWTestClient(t, readFails(&posts), WithFailOnReadError())
+		_, err := c.SendIfChanged(context.Background(), other)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected the read error, got: %v", err)
+		}
+		if posts != 0 {
+			t.Errorf("expected nothing to be sent, got %d posts", posts)
+		}
+	})
+}
+
+func TestRWSendTextEmpty(t *testing.T) {
+	t.Parallel()
+
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for an empty message")
+	})
+	for _, text := range []string{"", "   ", "\n \n"} {
+		if _, err := c.SendText(context.Background(), text); !errors.Is(err, ErrEmptyMessage) {
+			t.Errorf("%q: expected ErrEmptyMessage, got: %v", text, err)
+		}
+	}
+}
+
+func TestRWDoLayout(t *testing.T) {
+	t.Parallel()
+
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`not json`))
+	})
+
+	resp, body, err := c.DoLayout(context.Background(), NewLayout())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header.Get("X-Request-Id"); got != "req-1" {
+		t.Errorf("wrong header: %q", got)
+	}
+	if string(body) != "not json" {
+		t.Errorf("wrong body: %q", body)
+	}
+
+	var bad Layout
+	bad[0][0] = 99
+	if _, _, err := c.DoLayout(context.Background(), bad); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("expected ErrInvalidCode, got: %v", err)
+	}
+}
+
+func TestRWSendTextOpts(t *testing.T) {
+	t.Parallel()
+
+	var got TextMessage
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+	ctx := context.Background()
+
+	if _, err := c.SendTextOpts(ctx, "Hello"); err != nil || got.Text != "HELLO" {
+		t.Errorf("expected the default to uppercase, got: %q, %v", got.Text, err)
+	}
+	if _, err := c.SendTextOpts(ctx, "Hello", WithoutAutoUpper()); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected lowercase to be rejected, got: %v", err)
+	}
+	if _, err := c.SendTextOpts(ctx, "HELLO", WithoutAutoUpper()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := c.SendTextOpts(ctx, "HI ☕"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("expected the client's strict mode, got: %v", err)
+	}
+	if _, err := c.SendTextOpts(ctx, "HI ☕", WithTextSanitizeMode(SanitizeDrop)); err != nil || got.Text != "HI " {
+		t.Errorf("expected the character to be dropped, got: %q, %v", got.Text, err)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrEmptyMessage = errors.New("empty message")
+
+// SanitizeMode controls what happens to characters that can't be shown on the
+// board.
+type SanitizeMode int
+
+const (
+	// SanitizeStrict returns an error for unsupported characters.
+	SanitizeStrict SanitizeMode = iota
+	// SanitizeDrop removes unsupported characters.
+	SanitizeDrop
+	// SanitizeReplace replaces unsupported characters with a placeholder.
+	SanitizeReplace
+)
+
+// DefaultPlaceholder is the character used by SanitizeReplace.
+const DefaultPlaceholder = '?'
+
+// SanitizeOption configures SanitizeText.
+type SanitizeOption func(*sanitizeOptions)
+
+type sanitizeOptions struct {
+	placeholder rune
+	emojiColors bool
+}
+
+// WithPlaceholder sets the replacement character for SanitizeReplace. It must
+// be a character the board can show.
+func WithPlaceholder(r rune) SanitizeOption {
+	return func(o *sanitizeOptions) {
+		o.placeholder = r
+	}
+}
+
+// WithEmojiPassthrough keeps the emoji that Compose shows as colors with
+// WithEmojiColors, instead of treating them as unsupported characters.
+func WithEmojiPassthrough() SanitizeOption {
+	return func(o *sanitizeOptions) {
+		o.emojiColors = true
+	}
+}
+
+// keep returns true for characters that are left as they are.
+func (o *sanitizeOptions) keep(r rune) bool {
+	if r == '\n' {
+		return true
+	}
+	if o.emojiColors {
+		if _, ok := emojiColors[r]; ok || string(r) == emojiVariation {
+			return true
+		}
+	}
+	_, err := CharToCode(string(r))
+	return err == nil
+}
+
+// SanitizeText uppercases text and handles unsupported characters according
+// to mode. Newlines are kept. If nothing but whitespace is left of the text,
+// ErrEmptyMessage is returned.
+func SanitizeText(text string, mode SanitizeMode, opts ...SanitizeOption) (string, error) {
+	o := &sanitizeOptions{
+		placeholder: DefaultPlaceholder,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	text = strings.ToUpper(text)
+	var b strings.Builder
+	switch mode {
+	case SanitizeStrict:
+		for i, c := range text {
+			if !o.keep(c) {
+				return "", fmt.Errorf("invalid character %q at position %d, %w", string(c), i, ErrInvalidCharacter)
+			}
+		}
+		b.WriteString(text)
+	case SanitizeDrop, SanitizeReplace:
+		placeholder := string(o.placeholder)
+		if _, err := CharToCode(placeholder); err != nil {
+			return "", fmt.Errorf("invalid placeholder %q: %w", placeholder, err)
+		}
+		for _, c := range text {
+			if o.keep(c) {
+				b.WriteRune(c)
+			} else if mode == SanitizeReplace {
+				b.WriteString(placeholder)
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown sanitize mode %d", mode)
+	}
+
+	if strings.TrimSpace(b.String()) == "" {
+		return "", ErrEmptyMessage
+	}
+	return b.String(), nil
+}
+
+// UnsupportedRunes returns the distinct characters of text that can't be shown
+// on the board, in the order they first appear, i.e. to tell a user what
+// SanitizeText would drop. The text is uppercased first, newlines are
+// supported.
+func UnsupportedRunes(text string) []rune {
+	o := &sanitizeOptions{}
+	seen := make(map[rune]bool)
+	var runes []rune
+	for _, r := range strings.ToUpper(text) {
+		if o.keep(r) || seen[r] {
+			continue
+		}
+		seen[r] = true
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// TextOption configures a single SendTextOpts call.
+type TextOption func(*textOptions)
+
+type textOptions struct {
+	sanitizeMode SanitizeMode
+	sanitizeOpts []SanitizeOption
+	noAutoUpper  bool
+}
+
+// WithTextSanitizeMode overrides the client's sanitize mode for one message.
+func WithTextSanitizeMode(mode SanitizeMode, opts ...SanitizeOption) TextOption {
+	return func(o *textOptions) {
+		o.sanitizeMode = mode
+		o.sanitizeOpts = opts
+	}
+}
+
+// WithoutAutoUpper rejects text containing lowercase letters with
+// ErrInvalidCharacter instead of uppercasing it, regardless of the sanitize
+// mode.
+func WithoutAutoUpper() TextOption {
+	return func(o *textOptions) {
+		o.noAutoUpper = true
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		text    string
+		mode    SanitizeMode
+		opts    []SanitizeOption
+		want    string
+		wantErr error
+	}{
+		{name: "strict_ok", text: "Hello\nWorld", mode: SanitizeStrict, want: "HELLO\nWORLD"},
+		{name: "strict_invalid", text: "Café", mode: SanitizeStrict, wantErr: ErrInvalidCharacter},
+		{name: "drop", text: "Café ☕ time", mode: SanitizeDrop, want: "CAF  TIME"},
+		{name: "replace", text: "Café", mode: SanitizeReplace, want: "CAF?"},
+		{name: "replace_custom", text: "a™b", mode: SanitizeReplace, opts: []SanitizeOption{WithPlaceholder('-')}, want: "A-B"},
+		{name: "invalid_placeholder", text: "a™b", mode: SanitizeReplace, opts: []SanitizeOption{WithPlaceholder('™')}, wantErr: ErrInvalidCharacter},
+		{name: "drop_to_empty", text: "☕🙂", mode: SanitizeDrop, wantErr: ErrEmptyMessage},
+		{name: "empty", text: "", mode: SanitizeReplace, wantErr: ErrEmptyMessage},
+		{name: "whitespace", text: "  \n ", mode: SanitizeStrict, wantErr: ErrEmptyMessage},
+		{name: "emoji_strict", text: "ci 🟢", mode: SanitizeStrict, wantErr: ErrInvalidCharacter},
+		{name: "emoji_passthrough", text: "ci 🟢 🙂", mode: SanitizeDrop, opts: []SanitizeOption{WithEmojiPassthrough()}, want: "CI 🟢 "},
+		{name: "emoji_passthrough_strict", text: "ci 🔴️", mode: SanitizeStrict, opts: []SanitizeOption{WithEmojiPassthrough()}, want: "CI 🔴️"},
+		{name: "drop_to_whitespace", text: "🙂 🙂", mode: SanitizeDrop, wantErr: ErrEmptyMessage},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SanitizeText(tc.text, tc.mode, tc.opts...)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUnsupportedRunes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		text string
+		want []rune
+	}{
+		{text: "hello\nworld", want: nil},
+		{text: "", want: nil},
+		{text: "café™ café", want: []rune{'É', '™'}},
+		{text: "tab\there ☕☕", want: []rune{'\t', '☕'}},
+	}
+	for _, tc := range cases {
+		if got := UnsupportedRunes(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("UnsupportedRunes(%q), want: %q, got: %q", tc.text, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+)
+
+// LayoutFunc produces the layout to send for a scheduled entry.
+type LayoutFunc func(ctx context.Context) (Layout, error)
+
+// SchedulerOption configures a Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithScheduleErrorHandler sets a function that is called when an entry fails
+// to produce or send its layout. By default these errors are dropped.
+func WithScheduleErrorHandler(fn func(error)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.onError = fn
+	}
+}
+
+// Scheduler sends layouts at times given by cron specs. A run of an entry is
+// skipped if its previous run is still in progress.
+type Scheduler struct {
+	client  *RWClient
+	cron    *cron.Cron
+	onError func(error)
+}
+
+// NewScheduler creates a Scheduler that sends through c. It doesn't run until
+// Start is called.
+func NewScheduler(c *RWClient, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		client: c,
+		cron:   cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add schedules fn to run at the times given by spec, which is a standard
+// five field cron spec or a descriptor like "@hourly" or "@every 5m". The
+// layout fn returns is sent to the board.
+func (s *Scheduler) Add(spec string, fn LayoutFunc) error {
+	_, err := s.cron.AddFunc(spec, func() {
+		if err := s.run(fn); err != nil && s.onError != nil {
+			s.onError(fmt.Errorf("schedule %q: %w", spec, err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return nil
+}
+
+func (s *Scheduler) run(fn LayoutFunc) error {
+	ctx := context.Background()
+	l, err := fn(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.SendMessage(ctx, l)
+	return err
+}
+
+// Start runs the scheduler in the background.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling new runs and waits for running ones to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerInvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	s := NewScheduler(NewRWClient("key"))
+	if err := s.Add("not a spec", nil); err == nil {
+		t.Errorf("expected an error for an invalid spec")
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(0, 0, "SCHEDULED")
+	h := &boardHandler{t: t}
+	c := newRWTestClient(t, h.ServeHTTP)
+
+	errs := make(chan error, 10)
+	s := NewScheduler(c, WithScheduleErrorHandler(func(err error) {
+		errs <- err
+	}))
+
+	if err := s.Add("@every 1s", func(ctx context.Context) (Layout, error) {
+		return want, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	errFailed := errors.New("failed")
+	if err := s.Add("@every 1s", func(ctx context.Context) (Layout, error) {
+		return Layout{}, errFailed
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Runs of this entry overlap and must be skipped.
+	var slowRuns int32
+	release := make(chan struct{})
+	if err := s.Add("@every 1s", func(ctx context.Context) (Layout, error) {
+		atomic.AddInt32(&slowRuns, 1)
+		<-release
+		return Layout{}, errFailed
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start()
+	time.Sleep(2500 * time.Millisecond)
+	close(release)
+	s.Stop()
+
+	posted := h.Posted()
+	if len(posted) == 0 || posted[0] != want {
+		t.Errorf("expected the scheduled layout to be sent, got: %v", posted)
+	}
+	select {
+	case err := <-errs:
+		if !errors.Is(err, errFailed) {
+			t.Errorf("wrong error: %v", err)
+		}
+	default:
+		t.Errorf("expected the error handler to be called")
+	}
+	if n := atomic.LoadInt32(&slowRuns); n != 1 {
+		t.Errorf("expected overlapping runs to be skipped, got %d runs", n)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 var (
@@ -29,13 +30,33 @@ var (
 	ErrInvalidCoordinate = errors.New("invalid coordinate")
 )
 
-type Layout [6][22]int
+const (
+	// Rows is the number of rows on the board.
+	Rows = 6
+	// Columns is the number of characters in a row.
+	Columns = 22
+)
+
+type Layout [Rows][Columns]int
 
 func NewLayout() Layout {
 	var layout Layout
 	return layout
 }
 
+// Validate checks that every cell of the layout holds a valid character or
+// color code.
+func (l *Layout) Validate() error {
+	for x, row := range l {
+		for y, code := range row {
+			if !ValidCode(code) {
+				return fmt.Errorf("row %d, column %d: code %d: %w", x, y, code, ErrInvalidCode)
+			}
+		}
+	}
+	return nil
+}
+
 func (l *Layout) ValidCoordinate(x, y int) error {
 	if x < 0 || y < 0 || x > 5 || y > 21 {
 		return ErrInvalidCoordinate
@@ -74,7 +95,7 @@ func (l *Layout) SetColor(x, y int, c Color) error {
 	if err := l.ValidCoordinate(x, y); err != nil {
 		return err
 	}
-	if c < PoppyRed || c > White {
+	if !c.valid() {
 		return ErrInvalidColor
 	}
 	l[x][y] = int(c)
@@ -97,43 +118,99 @@ type Message struct {
 
 type MessageResponse struct {
 	Message `json:"message"`
+
+	// MessageID and CreatedAt are set from the message returned by the API.
+	MessageID string    `json:"-"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// setMetadata fills in the parsed message metadata.
+func (r *MessageResponse) setMetadata() {
+	r.MessageID = r.ID
+	r.CreatedAt = parseCreated(r.Created)
+}
+
+// parseCreated converts a creation timestamp of the API, which is in
+// milliseconds since the epoch, to a time. Timestamps that are too small to be
+// milliseconds are treated as seconds.
+func parseCreated(created int) time.Time {
+	if created <= 0 {
+		return time.Time{}
+	}
+	ts := int64(created)
+	if ts < 100_000_000_000 {
+		return time.Unix(ts, 0).UTC()
+	}
+	return time.Unix(0, ts*int64(time.Millisecond)).UTC()
 }
 
+// SendMessage posts a layout to the board of a subscription. The API has no
+// display duration, the message is shown until the board is changed again.
 func (c *Client) SendMessage(ctx context.Context, subscriptionID string, l Layout) (*MessageResponse, error) {
-	var b bytes.Buffer
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+	if err := c.checkQuietHours(func(ctx context.Context) error {
+		_, err := c.SendMessage(ctx, subscriptionID, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if c.dryRun != nil {
+		return c.dryRunMessage(l)
+	}
+
 	body := &LayoutMessage{
 		Layout: l,
 	}
-	if err := json.NewEncoder(&b).Encode(body); err != nil {
-		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	b, err := encodeLayoutRequest(body, len(`{"characters":}`))
+	if err != nil {
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s%s/%s/message", c.baseURL, subscriptionsPath, subscriptionID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &b)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, b)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set(APIKeyHeader, c.apiKey)
-	req.Header.Set(APIKeySecret, c.apiSecret)
 
 	var response MessageResponse
-	resp, err := c.do(req, &response)
-	if err != nil {
+	if _, err := c.do(req, &response); err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &response, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
+	response.setMetadata()
 	return &response, nil
 }
 
+// SendText posts a message to the board with the default formatting. Empty or
+// whitespace only text returns ErrEmptyMessage, send a blank layout with
+// SendMessage to clear the board.
 func (c *Client) SendText(ctx context.Context, subscriptionID string, text string) (*MessageResponse, error) {
-	text = strings.ToUpper(text)
-	if err := ValidText(text, true); err != nil {
-		return nil, fmt.Errorf("invalid message: %w", err)
+	return c.SendTextOpts(ctx, subscriptionID, text)
+}
+
+// SendTextOpts is SendText with options that control how the text is checked
+// before it is sent.
+func (c *Client) SendTextOpts(ctx context.Context, subscriptionID string, text string, opts ...TextOption) (*MessageResponse, error) {
+	text, err := c.prepareText(text, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// The queued send posts the prepared text, so that opts still apply.
+	if err := c.checkQuietHours(func(ctx context.Context) error {
+		_, err := c.postText(ctx, subscriptionID, text)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return c.postText(ctx, subscriptionID, text)
+}
+
+// postText posts text that prepareText has already checked.
+func (c *Client) postText(ctx context.Context, subscriptionID, text string) (*MessageResponse, error) {
+	if c.dryRun != nil {
+		return c.dryRunText(text)
 	}
 
 	var b bytes.Buffer
@@ -150,18 +227,11 @@ func (c *Client) SendText(ctx context.Context, subscriptionID string, text strin
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set(APIKeyHeader, c.apiKey)
-	req.Header.Set(APIKeySecret, c.apiSecret)
 
 	var response MessageResponse
-	resp, err := c.do(req, &response)
-	if err != nil {
+	if _, err := c.do(req, &response); err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return &response, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
+	response.setMetadata()
 	return &response, nil
 }
@@ -13,3 +13,90 @@
 // limitations under the License.
 
 package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionSendMessage(t *testing.T) {
+	t.Parallel()
+
+	want := NewLayout()
+	want.Print(1, 1, "SUBSCRIBED")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subscriptions/sub-id/message" {
+			t.Errorf("wrong path: %q", r.URL.Path)
+		}
+		if r.Header.Get(APIKeyHeader) != "key" || r.Header.Get(APIKeySecret) != "secret" {
+			t.Errorf("missing credentials: %v", r.Header)
+		}
+		var got LayoutMessage
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if got.Layout != want {
+			t.Errorf("wrong layout, want: %v, got: %v", want, got.Layout)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":{"id":"msg-id","created":1234}}`))
+	}))
+	defer srv.Close()
+
+	c := NewSubscriptionClient("key", "secret", WithBaseURL(srv.URL))
+	resp, err := c.SendMessage(context.Background(), "sub-id", want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ID != "msg-id" || resp.Created != 1234 {
+		t.Errorf("wrong response: %+v", resp)
+	}
+}
+
+func TestLayoutValidate(t *testing.T) {
+	t.Parallel()
+
+	l := NewLayout()
+	l.Print(0, 0, "VALID TEXT 123 °")
+	l.SetColor(5, 21, White)
+	if err := l.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, code := range []int{-1, 43, 45, 61, 72, 1000} {
+		bad := l
+		bad[4][7] = code
+		err := bad.Validate()
+		if !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("code %d: wrong error: %v", code, err)
+			continue
+		}
+		want := fmt.Sprintf("row 4, column 7: code %d", code)
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("code %d: error %q does not contain %q", code, err, want)
+		}
+	}
+}
+
+func TestParseCreated(t *testing.T) {
+	t.Parallel()
+
+	cases := map[int]time.Time{
+		0:             {},
+		1615000000:    time.Date(2021, 3, 6, 3, 6, 40, 0, time.UTC),
+		1615000000123: time.Date(2021, 3, 6, 3, 6, 40, 123_000_000, time.UTC),
+	}
+	for in, want := range cases {
+		if got := parseCreated(in); !got.Equal(want) {
+			t.Errorf("parseCreated(%d), want: %v, got: %v", in, want, got)
+		}
+	}
+}
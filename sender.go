@@ -0,0 +1,30 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+)
+
+// MessageSender is the part of RWClient that most programs need. Depend on it
+// instead of *RWClient to substitute a fake in tests, i.e. the FakeSender of
+// the testutil package.
+type MessageSender interface {
+	SendMessage(ctx context.Context, l Layout) (*MessageResponse, error)
+	SendText(ctx context.Context, text string) (*MessageResponse, error)
+	ReadMessage(ctx context.Context) (*Layout, error)
+}
+
+var _ MessageSender = (*RWClient)(nil)
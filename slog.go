@@ -0,0 +1,79 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package vestaboard
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs every request to l, successful requests at the debug level
+// and failed ones at the error level. Only the method, URL, status, duration
+// and sizes are logged, never the headers that hold the keys.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) {
+		o.logTransport = func(next http.RoundTripper) http.RoundTripper {
+			return newSlogTransport(next, l)
+		}
+	}
+}
+
+// slogTransport logs requests and responses to a structured logger.
+type slogTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func newSlogTransport(next http.RoundTripper, l *slog.Logger) *slogTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &slogTransport{
+		next:   next,
+		logger: l,
+	}
+}
+
+func (t *slogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int64("request_bytes", req.ContentLength),
+	}
+	ctx := req.Context()
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		t.logger.LogAttrs(ctx, slog.LevelError, "vestaboard request failed", attrs...)
+		return nil, err
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.Int64("response_bytes", resp.ContentLength))
+	level := slog.LevelDebug
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		level = slog.LevelError
+	}
+	t.logger.LogAttrs(ctx, level, "vestaboard request", attrs...)
+	return resp, nil
+}
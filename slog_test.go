@@ -0,0 +1,79 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package vestaboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var fail int32
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			http.Error(w, "nope", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}, WithLogger(logger))
+
+	if _, err := c.SendText(context.Background(), "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	atomic.StoreInt32(&fail, 1)
+	if _, err := c.SendText(context.Background(), "hi"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if strings.Contains(buf.String(), "rw-key") {
+		t.Errorf("key leaked into log: %s", buf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got: %q", lines)
+	}
+	for i, want := range []struct {
+		level  string
+		status float64
+	}{{"DEBUG", 200}, {"ERROR", 500}} {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+			t.Fatalf("failed to decode log line: %v", err)
+		}
+		if entry["level"] != want.level || entry["status"] != want.status || entry["method"] != http.MethodPost {
+			t.Errorf("wrong log entry %d: %v", i, entry)
+		}
+		for _, attr := range []string{"url", "duration", "request_bytes", "response_bytes"} {
+			if _, ok := entry[attr]; !ok {
+				t.Errorf("log entry %d is missing %s: %v", i, attr, entry)
+			}
+		}
+	}
+}
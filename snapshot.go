@@ -0,0 +1,154 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Snapshot returns the layout currently shown on the board, so that it can be
+// restored later with SendMessage. A board that has never been written to is
+// returned as a blank layout.
+func (c *RWClient) Snapshot(ctx context.Context) (Layout, error) {
+	l, err := c.ReadMessage(ctx)
+	if errors.Is(err, ErrNoCurrentMessage) {
+		return NewLayout(), nil
+	}
+	if err != nil {
+		return Layout{}, err
+	}
+	return *l, nil
+}
+
+// ChangedCellCount reads the board and returns how many cells would flip if l
+// was sent, i.e. to skip sends that barely change the board. A board that has
+// never been written to is compared as blank.
+func (c *RWClient) ChangedCellCount(ctx context.Context, l Layout) (int, error) {
+	current, err := c.Snapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(current.Diff(l)), nil
+}
+
+// SendAndConfirm posts l and then reads the board every poll until it shows l,
+// so that a sequence of sends can wait for the flaps to settle. Use a context
+// deadline to bound the wait, when ctx is done, the error says how many cells
+// still differed.
+func (c *RWClient) SendAndConfirm(ctx context.Context, l Layout, poll time.Duration) error {
+	if _, err := c.SendMessage(ctx, l); err != nil {
+		return err
+	}
+
+	differ := -1
+	notConfirmed := func() error {
+		return fmt.Errorf("board not confirmed, %d cells still differ: %w", differ, ctx.Err())
+	}
+	for {
+		current, err := c.Snapshot(ctx)
+		if err != nil {
+			// A read that was cut short by ctx still reports the last count.
+			if ctx.Err() != nil && differ > 0 {
+				return notConfirmed()
+			}
+			return fmt.Errorf("failed to read board: %w", err)
+		}
+		differ = len(current.Diff(l))
+		if differ == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return notConfirmed()
+		case <-c.clock.After(poll):
+		}
+	}
+}
+
+// AppendLine adds text as a new left aligned row below what the board shows,
+// like a console. Blank rows at the bottom are filled first, once the last
+// row is in use, the rows scroll up by one. Text longer than a row returns
+// ErrMessageTruncated.
+func (c *RWClient) AppendLine(ctx context.Context, text string) error {
+	text, err := c.prepareText(text)
+	if err != nil {
+		return err
+	}
+	if n := utf8.RuneCountInString(text); n > Columns {
+		return fmt.Errorf("text is %d characters, the maximum is %d: %w", n, Columns, ErrMessageTruncated)
+	}
+
+	l, err := c.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read board: %w", err)
+	}
+
+	// The new line goes below the last row that is in use.
+	row := 0
+	for x := Rows - 1; x >= 0; x-- {
+		if l[x] != [Columns]int{} {
+			row = x + 1
+			break
+		}
+	}
+	if row == Rows {
+		copy(l[:], l[1:])
+		row = Rows - 1
+	}
+	l[row] = [Columns]int{}
+	if err := l.Print(row, 0, text); err != nil {
+		return err
+	}
+
+	_, err = c.SendMessage(ctx, l)
+	return err
+}
+
+// WithTemporary shows alert for d and then restores whatever was on the board
+// before. The board is restored even if ctx is done, or sending the alert
+// fails, since the alert may already have reached the board. In that case the
+// restore is bounded by the client's timeout rather than ctx.
+func (c *RWClient) WithTemporary(ctx context.Context, alert Layout, d time.Duration) (err error) {
+	snapshot, err := c.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot board: %w", err)
+	}
+
+	defer func() {
+		restoreCtx := ctx
+		if ctx.Err() != nil {
+			restoreCtx = context.Background()
+		}
+		if _, rerr := c.SendMessage(restoreCtx, snapshot); rerr != nil && err == nil {
+			err = fmt.Errorf("failed to restore board: %w", rerr)
+		}
+	}()
+
+	if _, err := c.SendMessage(ctx, alert); err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.clock.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// boardHandler fakes the Read/Write API, it remembers every posted layout.
+type boardHandler struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	current *Layout
+	posted  []Layout
+}
+
+func (b *boardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet {
+		if b.current == nil {
+			w.Write([]byte(`{}`))
+			return
+		}
+		encoded, _ := json.Marshal(b.current)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"currentMessage": map[string]interface{}{"layout": string(encoded)},
+		})
+		return
+	}
+
+	var l Layout
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		b.t.Errorf("failed to decode body: %v", err)
+	}
+	b.current = &l
+	b.posted = append(b.posted, l)
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (b *boardHandler) Posted() []Layout {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Layout(nil), b.posted...)
+}
+
+func TestWithTemporary(t *testing.T) {
+	t.Parallel()
+
+	before := NewLayout()
+	before.Print(0, 0, "BEFORE")
+	alert := NewLayout()
+	alert.Print(0, 0, "ALERT")
+
+	cases := []struct {
+		name    string
+		initial *Layout
+		cancel  bool
+		wantErr error
+		restore Layout
+	}{
+		{
+			name:    "restores",
+			initial: &before,
+			restore: before,
+		},
+		{
+			name:    "blank_board",
+			restore: NewLayout(),
+		},
+		{
+			name:    "cancelled",
+			initial: &before,
+			cancel:  true,
+			wantErr: context.Canceled,
+			restore: before,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &boardHandler{t: t, current: tc.initial}
+			c := newRWTestClient(t, h.ServeHTTP)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			d := 10 * time.Millisecond
+			if tc.cancel {
+				d = time.Hour
+				go func() {
+					for len(h.Posted()) == 0 {
+						time.Sleep(5 * time.Millisecond)
+					}
+					cancel()
+				}()
+			}
+
+			err := c.WithTemporary(ctx, alert, d)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+
+			posted := h.Posted()
+			if len(posted) != 2 {
+				t.Fatalf("expected alert and restore, got %d messages", len(posted))
+			}
+			if posted[0] != alert {
+				t.Errorf("wrong alert: %v", posted[0])
+			}
+			if posted[1] != tc.restore {
+				t.Errorf("wrong restore, want: %v, got: %v", tc.restore, posted[1])
+			}
+		})
+	}
+}
+
+func TestSendAndConfirm(t *testing.T) {
+	t.Parallel()
+
+	before := NewLayout()
+	before.Print(0, 0, "BEFORE")
+	want := NewLayout()
+	want.Print(0, 0, "AFTER")
+
+	cases := []struct {
+		name    string
+		settle  int
+		wantErr error
+	}{
+		{name: "immediate"},
+		{name: "settles", settle: 3},
+		{name: "never", settle: -1, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The first reads see the previous layout, as if the flaps were
+			// still turning.
+			h := &boardHandler{t: t}
+			stale := &boardHandler{t: t, current: &before}
+			var mu sync.Mutex
+			reads := 0
+			clock := &fakeClock{}
+			c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				isStale := r.Method == http.MethodGet && (tc.settle < 0 || reads < tc.settle)
+				if r.Method == http.MethodGet {
+					reads++
+				}
+				mu.Unlock()
+				if isStale {
+					stale.ServeHTTP(w, r)
+					return
+				}
+				h.ServeHTTP(w, r)
+			}, WithClock(clock))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			err := c.SendAndConfirm(ctx, want, time.Second)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("wrong error, want: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				if n := len(before.Diff(want)); !strings.Contains(err.Error(), fmt.Sprintf("%d cells still differ", n)) {
+					t.Errorf("error doesn't say how many cells differ: %v", err)
+				}
+				return
+			}
+			if got := len(clock.Delays()); got != tc.settle {
+				t.Errorf("wrong number of polls, want: %d, got: %d", tc.settle, got)
+			}
+		})
+	}
+}
+
+func TestAppendLine(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := &boardHandler{t: t}
+	c := newRWTestClient(t, h.ServeHTTP)
+
+	// The blank rows are used first.
+	for i := 0; i < Rows; i++ {
+		if err := c.AppendLine(ctx, fmt.Sprintf("line %d", i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// Then the rows scroll up.
+	if err := c.AppendLine(ctx, "line 6"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	posted := h.Posted()
+	if len(posted) != Rows+1 {
+		t.Fatalf("wrong number of sends: %d", len(posted))
+	}
+	if got, want := rowText(posted[0], 0), "LINE 0"+strings.Repeat(" ", Columns-6); got != want {
+		t.Errorf("first send, want: %q, got: %q", want, got)
+	}
+	if got := rowText(posted[0], 1); got != strings.Repeat(" ", Columns) {
+		t.Errorf("first send should only use the first row, got: %q", got)
+	}
+	last := posted[len(posted)-1]
+	for row := 0; row < Rows; row++ {
+		want := fmt.Sprintf("LINE %d", row+1) + strings.Repeat(" ", Columns-6)
+		if got := rowText(last, row); got != want {
+			t.Errorf("row %d, want: %q, got: %q", row, want, got)
+		}
+	}
+
+	if err := c.AppendLine(ctx, strings.Repeat("X", Columns+1)); !errors.Is(err, ErrMessageTruncated) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if err := c.AppendLine(ctx, " "); !errors.Is(err, ErrEmptyMessage) {
+		t.Errorf("wrong error: %v", err)
+	}
+	if n := len(h.Posted()); n != Rows+1 {
+		t.Errorf("invalid lines were sent, got %d sends", n)
+	}
+}
+
+func TestChangedCellCount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := &boardHandler{t: t}
+	c := newRWTestClient(t, h.ServeHTTP)
+
+	next := NewLayout()
+	next.Print(0, 0, "HELLO")
+
+	// A board without a message counts as blank.
+	if n, err := c.ChangedCellCount(ctx, next); err != nil || n != 5 {
+		t.Errorf("blank board, want: 5, got: %d, err: %v", n, err)
+	}
+
+	if _, err := c.SendMessage(ctx, next); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := c.ChangedCellCount(ctx, next); err != nil || n != 0 {
+		t.Errorf("same layout, want: 0, got: %d, err: %v", n, err)
+	}
+	next.Print(0, 0, "HELP")
+	if n, err := c.ChangedCellCount(ctx, next); err != nil || n != 1 {
+		t.Errorf("one letter changed, want: 1, got: %d, err: %v", n, err)
+	}
+	if n := len(h.Posted()); n != 1 {
+		t.Errorf("ChangedCellCount must not send, got %d sends", n)
+	}
+}
@@ -16,11 +16,24 @@ package vestaboard
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 )
 
 const subscriptionsPath = "/subscriptions"
 
+// SubscriptionClient is a client for the Subscription API, which uses an API
+// key and secret and can post to every board that the installation is
+// subscribed to. It is the same type as Client.
+type SubscriptionClient = Client
+
+// NewSubscriptionClient creates a client for the Subscription API. It is
+// equivalent to New.
+func NewSubscriptionClient(apiKey, apiSecret string, opts ...Option) *SubscriptionClient {
+	return New(apiKey, apiSecret, opts...)
+}
+
 type Subscription struct {
 	ID           string `json:"_id"`
 	Created      string `json:"_created"`
@@ -39,8 +52,6 @@ func (c *Client) Subscriptions(ctx context.Context) (*SubscriptionsResponse, err
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set(APIKeyHeader, c.apiKey)
-	req.Header.Set(APIKeySecret, c.apiSecret)
 
 	var response SubscriptionsResponse
 	_, err = c.do(req, &response)
@@ -49,3 +60,39 @@ func (c *Client) Subscriptions(ctx context.Context) (*SubscriptionsResponse, err
 	}
 	return &response, nil
 }
+
+// HistoryEntry is a message that was shown on a subscribed board.
+type HistoryEntry struct {
+	ID      string `json:"id"`
+	Created int    `json:"created"`
+	Text    string `json:"text,omitempty"`
+	// Layout is nil if the API didn't return the character codes.
+	Layout *Layout `json:"characters,omitempty"`
+
+	// CreatedAt is parsed from Created.
+	CreatedAt time.Time `json:"-"`
+}
+
+type messagesResponse struct {
+	Messages []HistoryEntry `json:"messages"`
+}
+
+// Messages returns the recent messages of a subscription, as far back as the
+// API keeps them. The API returns them in a single response.
+func (c *Client) Messages(ctx context.Context, subscriptionID string) ([]HistoryEntry, error) {
+	url := fmt.Sprintf("%s%s/%s/messages", c.baseURL, subscriptionsPath, subscriptionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var response messagesResponse
+	if _, err := c.do(req, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.Messages {
+		response.Messages[i].CreatedAt = parseCreated(response.Messages[i].Created)
+	}
+	return response.Messages, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMessages(t *testing.T) {
+	t.Parallel()
+
+	shown := NewLayout()
+	shown.Print(0, 0, "LANDED")
+	encoded, err := json.Marshal(shown)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/subscriptions/sub-id/messages" {
+			t.Errorf("wrong request: %v %v", r.Method, r.URL.Path)
+		}
+		if r.Header.Get(APIKeyHeader) != "key" || r.Header.Get(APIKeySecret) != "secret" {
+			t.Errorf("missing credentials: %v", r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"messages":[` +
+			`{"id":"new","created":1615000000000,"characters":` + string(encoded) + `},` +
+			`{"id":"old","created":1614000000000,"text":"HELLO"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewSubscriptionClient("key", "secret", WithBaseURL(srv.URL))
+	got, err := c.Messages(context.Background(), "sub-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(got))
+	}
+	if got[0].ID != "new" || got[0].Layout == nil || *got[0].Layout != shown {
+		t.Errorf("wrong first message: %+v", got[0])
+	}
+	if want := time.Date(2021, 3, 6, 3, 6, 40, 0, time.UTC); !got[0].CreatedAt.Equal(want) {
+		t.Errorf("wrong created time, want: %v, got: %v", want, got[0].CreatedAt)
+	}
+	if got[1].Text != "HELLO" || got[1].Layout != nil {
+		t.Errorf("wrong second message: %+v", got[1])
+	}
+}
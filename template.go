@@ -0,0 +1,59 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// RenderTemplate executes a text/template with data and composes the result
+// onto a layout.
+func RenderTemplate(tmpl string, data interface{}, opts ...ComposeOption) (Layout, error) {
+	t, err := template.New("layout").Parse(tmpl)
+	if err != nil {
+		return NewLayout(), fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return NewLayout(), fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	text := b.String()
+	if err := checkSubstrings(strings.ToUpper(text), newComposeOptions(opts)); err != nil {
+		return NewLayout(), err
+	}
+	return Compose(text, opts...)
+}
+
+// checkSubstrings reports the first word of text that has a character the
+// board can't show with the compose options o, i.e. emoji are accepted with
+// WithEmojiColors.
+func checkSubstrings(text string, o *composeOptions) error {
+	if o.emojiColors {
+		text = strings.ReplaceAll(text, emojiVariation, "")
+	}
+	for _, word := range strings.FieldsFunc(text, unicode.IsSpace) {
+		for _, c := range word {
+			if _, ok := o.encode(c); !ok {
+				return fmt.Errorf("invalid character %q in %q: %w", string(c), word, ErrInvalidCharacter)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]string{"Name": "Alex", "Time": "10:30"}
+	got, err := RenderTemplate("standup at {{.Time}} - {{.Name}} hosts", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := Compose("STANDUP AT 10:30 - ALEX HOSTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("wrong layout:\n%s", Decode(got))
+	}
+
+	_, err = RenderTemplate("on call: {{.Name}}", map[string]string{"Name": "José"})
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if !strings.Contains(err.Error(), `"JOSÉ"`) {
+		t.Errorf("error doesn't point at the substring: %v", err)
+	}
+
+	// Color emoji in the data become color tiles with WithEmojiColors.
+	status := map[string]string{"Build": "🟢", "Deploy": "🔴\uFE0F"}
+	got, err = RenderTemplate("build {{.Build}} deploy {{.Deploy}}", status, WithEmojiColors(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, err = Compose("build 🟢 deploy 🔴", WithEmojiColors(true)); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("wrong layout with emoji colors:\n%s", Decode(got))
+	}
+	if _, err := RenderTemplate("build {{.Build}}", status); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("emoji without WithEmojiColors, wrong error: %v", err)
+	}
+
+	if _, err := RenderTemplate("{{.Missing", nil); err == nil {
+		t.Errorf("expected parse error")
+	}
+}
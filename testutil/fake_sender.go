@@ -0,0 +1,119 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mikehelmick/go-vestaboard"
+)
+
+// Call is a call that was made to a FakeSender.
+type Call struct {
+	// Method is SendMessage, SendText or ReadMessage.
+	Method string
+	Layout vestaboard.Layout
+	Text   string
+}
+
+// FakeSender is an in-memory vestaboard.MessageSender that records every call.
+// Sent layouts and composed text become the current layout. The zero value is
+// ready to use and it is safe for concurrent use.
+type FakeSender struct {
+	mu      sync.Mutex
+	calls   []Call
+	current *vestaboard.Layout
+	err     error
+}
+
+var _ vestaboard.MessageSender = (*FakeSender)(nil)
+
+// SetError makes every following call fail with err, nil restores success.
+func (f *FakeSender) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Calls returns the calls that were made, in order.
+func (f *FakeSender) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// SendMessage records l and makes it the current layout.
+func (f *FakeSender) SendMessage(ctx context.Context, l vestaboard.Layout) (*vestaboard.MessageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call{Method: "SendMessage", Layout: l})
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.show(l), nil
+}
+
+// SendText records text and makes it the current layout, composed with the
+// default formatting. Like the RWClient, empty or whitespace only text returns
+// vestaboard.ErrEmptyMessage.
+func (f *FakeSender) SendText(ctx context.Context, text string) (*vestaboard.MessageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call{Method: "SendText", Text: text})
+	if f.err != nil {
+		return nil, f.err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, vestaboard.ErrEmptyMessage
+	}
+	l, err := vestaboard.Compose(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	return f.show(l), nil
+}
+
+// ReadMessage returns the current layout, or vestaboard.ErrNoCurrentMessage if
+// nothing was sent yet.
+func (f *FakeSender) ReadMessage(ctx context.Context) (*vestaboard.Layout, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call{Method: "ReadMessage"})
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.current == nil {
+		return nil, vestaboard.ErrNoCurrentMessage
+	}
+	l := *f.current
+	return &l, nil
+}
+
+func (f *FakeSender) show(l vestaboard.Layout) *vestaboard.MessageResponse {
+	f.current = &l
+	return &vestaboard.MessageResponse{
+		Message: vestaboard.Message{
+			ID:   fmt.Sprintf("fake-%d", len(f.calls)),
+			Text: "ok",
+		},
+		MessageID: fmt.Sprintf("fake-%d", len(f.calls)),
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mikehelmick/go-vestaboard"
+)
+
+func TestFakeSender(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var f FakeSender
+
+	if _, err := f.ReadMessage(ctx); !errors.Is(err, vestaboard.ErrNoCurrentMessage) {
+		t.Errorf("expected ErrNoCurrentMessage, got: %v", err)
+	}
+
+	l := vestaboard.NewLayout()
+	l.Print(0, 0, "FAKE")
+	if _, err := f.SendMessage(ctx, l); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, err := f.ReadMessage(ctx); err != nil || *got != l {
+		t.Errorf("wrong current layout: %v, %v", got, err)
+	}
+
+	if _, err := f.SendText(ctx, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := vestaboard.Compose("hello")
+	if got, err := f.ReadMessage(ctx); err != nil || *got != want {
+		t.Errorf("text message wasn't composed: %v, %v", got, err)
+	}
+
+	for _, text := range []string{"", " \n "} {
+		if _, err := f.SendText(ctx, text); !errors.Is(err, vestaboard.ErrEmptyMessage) {
+			t.Errorf("%q: want error %v, got: %v", text, vestaboard.ErrEmptyMessage, err)
+		}
+	}
+	if got, err := f.ReadMessage(ctx); err != nil || *got != want {
+		t.Errorf("empty text changed the current layout: %v, %v", got, err)
+	}
+
+	boom := errors.New("boom")
+	f.SetError(boom)
+	if _, err := f.SendText(ctx, "fails"); !errors.Is(err, boom) {
+		t.Errorf("wrong error: %v", err)
+	}
+
+	wantCalls := []Call{
+		{Method: "ReadMessage"},
+		{Method: "SendMessage", Layout: l},
+		{Method: "ReadMessage"},
+		{Method: "SendText", Text: "hello"},
+		{Method: "ReadMessage"},
+		{Method: "SendText", Text: ""},
+		{Method: "SendText", Text: " \n "},
+		{Method: "ReadMessage"},
+		{Method: "SendText", Text: "fails"},
+	}
+	if got := f.Calls(); !reflect.DeepEqual(got, wantCalls) {
+		t.Errorf("wrong calls, want: %v, got: %v", wantCalls, got)
+	}
+}
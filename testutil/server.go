@@ -0,0 +1,185 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil provides a fake Vestaboard Read/Write API server and a fake
+// MessageSender for tests.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/mikehelmick/go-vestaboard"
+	"golang.org/x/time/rate"
+)
+
+// Server is an httptest.Server that speaks the Read/Write API. It accepts
+// requests with the configured key, stores the last posted layout, and serves
+// it back on GET.
+type Server struct {
+	*httptest.Server
+	key string
+
+	mu       sync.Mutex
+	last     vestaboard.Layout
+	written  bool
+	messages int
+}
+
+// NewServer starts a server that accepts the given read/write key. The caller
+// must call Close when done.
+func NewServer(key string) *Server {
+	s := &Server{
+		key: key,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// RWClient returns a client that talks to the server, with rate limiting
+// disabled.
+func (s *Server) RWClient(opts ...vestaboard.Option) *vestaboard.RWClient {
+	opts = append([]vestaboard.Option{
+		vestaboard.WithBaseURL(s.URL),
+		vestaboard.WithHTTPClient(s.Client()),
+		vestaboard.WithRateLimit(rate.Inf, 0),
+	}, opts...)
+	return vestaboard.NewRWClient(s.key, opts...)
+}
+
+// LastLayout returns the most recently posted layout.
+func (s *Server) LastLayout() vestaboard.Layout {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Messages returns the number of messages that were posted.
+func (s *Server) Messages() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages
+}
+
+// SetLayout replaces the layout that the server reports as displayed.
+func (s *Server) SetLayout(l vestaboard.Layout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = l
+	s.written = true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(vestaboard.RWAPIKeyHeader) != s.key {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "invalid read/write key"})
+		return
+	}
+	if r.URL.Path != "/" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleRead(w)
+	case http.MethodPost:
+		s.handleWrite(w, r)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "method not allowed"})
+	}
+}
+
+func (s *Server) handleRead(w http.ResponseWriter) {
+	s.mu.Lock()
+	last, written, id := s.last, s.written, s.messages
+	s.mu.Unlock()
+
+	if !written {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "no current message"})
+		return
+	}
+
+	// Like the real API, the layout is a string containing JSON.
+	encoded, err := json.Marshal(last)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"message": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"currentMessage": map[string]string{
+			"id":     fmt.Sprintf("message-%d", id),
+			"layout": string(encoded),
+		},
+	})
+}
+
+func (s *Server) handleWrite(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, vestaboard.MaxBodySize))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	l, err := decodeMessage(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	s.last = l
+	s.written = true
+	s.messages++
+	id := s.messages
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  "ok",
+		"id":      fmt.Sprintf("message-%d", id),
+		"created": time.Now().UnixNano() / int64(time.Millisecond),
+	})
+}
+
+// decodeMessage accepts either a layout or a text message.
+func decodeMessage(body []byte) (vestaboard.Layout, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		l, err := vestaboard.LoadLayoutJSON(bytes.NewReader(body))
+		if err != nil {
+			return l, fmt.Errorf("invalid layout: %w", err)
+		}
+		return l, nil
+	}
+
+	var msg vestaboard.TextMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return vestaboard.NewLayout(), fmt.Errorf("invalid message: %w", err)
+	}
+	l, err := vestaboard.Compose(msg.Text)
+	if err != nil {
+		return l, fmt.Errorf("invalid text: %w", err)
+	}
+	return l, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mikehelmick/go-vestaboard"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer("test-key")
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := srv.RWClient()
+
+	if _, err := c.ReadMessage(ctx); !errors.Is(err, vestaboard.ErrNoCurrentMessage) {
+		t.Errorf("expected ErrNoCurrentMessage, got: %v", err)
+	}
+
+	want := vestaboard.NewLayout()
+	want.Print(1, 1, "TESTING")
+	if _, err := c.SendMessage(ctx, want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := srv.LastLayout(); got != want {
+		t.Errorf("wrong stored layout, want: %v, got: %v", want, got)
+	}
+
+	got, err := c.ReadMessage(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got != want {
+		t.Errorf("wrong read layout, want: %v, got: %v", want, *got)
+	}
+
+	if _, err := c.SendText(ctx, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text, _ := vestaboard.Compose("hello"); srv.LastLayout() != text {
+		t.Errorf("text message wasn't composed")
+	}
+	if got := srv.Messages(); got != 2 {
+		t.Errorf("wrong message count, want: 2, got: %d", got)
+	}
+
+	bad := vestaboard.NewRWClient("wrong-key", vestaboard.WithBaseURL(srv.URL))
+	_, err = bad.SendMessage(ctx, want)
+	var apiErr *vestaboard.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	attrHTTPMethod     = attribute.Key("http.method")
+	attrHTTPURL        = attribute.Key("http.url")
+	attrHTTPStatusCode = attribute.Key("http.status_code")
+	attrRequestSize    = attribute.Key("http.request_content_length")
+)
+
+// doTraced wraps a request, including its retries, in a client span and
+// injects the trace context into the request headers.
+func (c *baseClient) doTraced(req *http.Request, out interface{}) (*http.Response, error) {
+	ctx, span := c.tracer.Start(req.Context(), "vestaboard "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attrHTTPMethod.String(req.Method),
+			attrHTTPURL.String(req.URL.String()),
+			attrRequestSize.Int64(req.ContentLength),
+		))
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.doAttempts(req, out)
+	if code := statusCode(resp, err); code != 0 {
+		span.SetAttributes(attrHTTPStatusCode.Int(code))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return resp, nil
+}
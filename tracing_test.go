@@ -0,0 +1,120 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeTracer records the spans it starts, without pulling in the SDK.
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &fakeSpan{
+		name:  name,
+		attrs: map[attribute.Key]attribute.Value{},
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{2},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	s.SetAttributes(cfg.Attributes()...)
+	t.spans = append(t.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type fakeSpan struct {
+	trace.Span
+
+	name   string
+	sc     trace.SpanContext
+	attrs  map[attribute.Key]attribute.Value
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *fakeSpan) End(...trace.SpanEndOption)                    { s.ended = true }
+func (s *fakeSpan) IsRecording() bool                             { return !s.ended }
+func (s *fakeSpan) SpanContext() trace.SpanContext                { return s.sc }
+func (s *fakeSpan) SetStatus(c codes.Code, _ string)              { s.status = c }
+func (s *fakeSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *fakeSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	var traceparent string
+	fail := false
+	tracer := &fakeTracer{}
+	c := newRWTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		if fail {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}, WithTracer(tracer))
+
+	if _, err := c.SendText(context.Background(), "TRACED"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tracer.spans) != 1 {
+		t.Fatalf("wrong number of spans: %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if !span.ended {
+		t.Errorf("span was not ended")
+	}
+	if got := span.attrs[attrHTTPMethod].AsString(); got != http.MethodPost {
+		t.Errorf("wrong method attribute: %q", got)
+	}
+	if got := span.attrs[attrHTTPStatusCode].AsInt64(); got != http.StatusOK {
+		t.Errorf("wrong status attribute: %d", got)
+	}
+	if got := span.attrs[attrRequestSize].AsInt64(); got <= 0 {
+		t.Errorf("wrong size attribute: %d", got)
+	}
+	if !strings.HasPrefix(traceparent, "00-01000000000000000000000000000000-0200000000000000-01") {
+		t.Errorf("trace context not propagated: %q", traceparent)
+	}
+
+	fail = true
+	if _, err := c.SendText(context.Background(), "TRACED"); err == nil {
+		t.Fatalf("expected error")
+	}
+	span = tracer.spans[1]
+	if span.status != codes.Error || len(span.errs) != 1 {
+		t.Errorf("error not recorded on span: %v, %v", span.status, span.errs)
+	}
+	if got := span.attrs[attrHTTPStatusCode].AsInt64(); got != http.StatusUnauthorized {
+		t.Errorf("wrong status attribute: %d", got)
+	}
+}
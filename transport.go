@@ -0,0 +1,74 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// TransportTuning configures the connection pool of the client's transport.
+// Zero fields keep the setting of the transport.
+type TransportTuning struct {
+	// MaxIdleConns limits the idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the idle connections to the API host, the
+	// net/http default is 2.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+}
+
+// modifyTransport returns a copy of rt changed by fn. Transports other than
+// *http.Transport are returned as is.
+func modifyTransport(rt http.RoundTripper, fn func(*http.Transport)) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+
+	t = t.Clone()
+	fn(t)
+	return t
+}
+
+// insecureTransport returns a copy of rt that doesn't verify TLS certificates.
+func insecureTransport(rt http.RoundTripper) http.RoundTripper {
+	return modifyTransport(rt, func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.InsecureSkipVerify = true
+	})
+}
+
+// tunedTransport returns a copy of rt with the pool settings of tuning.
+func tunedTransport(rt http.RoundTripper, tuning TransportTuning) http.RoundTripper {
+	return modifyTransport(rt, func(t *http.Transport) {
+		if tuning.MaxIdleConns > 0 {
+			t.MaxIdleConns = tuning.MaxIdleConns
+		}
+		if tuning.MaxIdleConnsPerHost > 0 {
+			t.MaxIdleConnsPerHost = tuning.MaxIdleConnsPerHost
+		}
+		if tuning.IdleConnTimeout > 0 {
+			t.IdleConnTimeout = tuning.IdleConnTimeout
+		}
+	})
+}
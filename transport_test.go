@@ -0,0 +1,97 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	// Without the option the self-signed certificate is rejected.
+	if err := NewLocalClient(srv.URL, "key").WriteMessage(ctx, NewLayout()); err == nil {
+		t.Fatal("expected a certificate error")
+	}
+	if err := NewLocalClient(srv.URL, "key", WithInsecureSkipVerify()).WriteMessage(ctx, NewLayout()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The given client isn't changed.
+	hc := &http.Client{Transport: &http.Transport{}}
+	c := NewLocalClient(srv.URL, "key", WithHTTPClient(hc), WithInsecureSkipVerify())
+	if err := c.WriteMessage(ctx, NewLayout()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := hc.Transport.(*http.Transport).TLSClientConfig; cfg != nil && cfg.InsecureSkipVerify {
+		t.Errorf("provided transport was modified")
+	}
+}
+
+func TestWithTransportTuning(t *testing.T) {
+	t.Parallel()
+
+	base := &http.Transport{MaxIdleConns: 10}
+	c := NewLocalClient("https://board.local", "key", WithTransport(base),
+		WithTransportTuning(TransportTuning{MaxIdleConnsPerHost: 8, IdleConnTimeout: time.Minute}))
+
+	kt, ok := c.httpClient.Transport.(*keyTransport)
+	if !ok {
+		t.Fatalf("outermost transport is %T, want *keyTransport", c.httpClient.Transport)
+	}
+	tuned, ok := kt.next.(*http.Transport)
+	if !ok {
+		t.Fatalf("wrapped transport is %T, want *http.Transport", kt.next)
+	}
+	if tuned == base {
+		t.Fatal("provided transport was modified")
+	}
+	if got, want := tuned.MaxIdleConns, 10; got != want {
+		t.Errorf("MaxIdleConns = %d, want %d", got, want)
+	}
+	if got, want := tuned.MaxIdleConnsPerHost, 8; got != want {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", got, want)
+	}
+	if got, want := tuned.IdleConnTimeout, time.Minute; got != want {
+		t.Errorf("IdleConnTimeout = %v, want %v", got, want)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.Header.Clone()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}}, nil
+	})
+	// Tuning doesn't apply to a custom transport, it is kept as is.
+	c := NewLocalClient("https://board.local", "key", WithTransport(rt),
+		WithTransportTuning(TransportTuning{MaxIdleConns: 1}))
+	if err := c.WriteMessage(context.Background(), NewLayout()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get(LocalAPIKeyHeader) != "key" {
+		t.Errorf("custom transport didn't get the key, headers: %v", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrColorInText = errors.New("color tile inside text")
+
+// Cell is the position of a cell on the board.
+type Cell struct {
+	Row int
+	Col int
+}
+
+// ColorInTextError lists color tiles that ValidateText found inside text. Use
+// errors.As to get the positions.
+type ColorInTextError struct {
+	Cells []Cell
+}
+
+func (e *ColorInTextError) Error() string {
+	positions := make([]string, 0, len(e.Cells))
+	for _, c := range e.Cells {
+		positions = append(positions, fmt.Sprintf("row %d, column %d", c.Row, c.Col))
+	}
+	return fmt.Sprintf("%v: %s", ErrColorInText, strings.Join(positions, "; "))
+}
+
+func (e *ColorInTextError) Unwrap() error {
+	return ErrColorInText
+}
+
+// ValidateText is a stricter check than Validate for layouts that are meant to
+// show text. It reports color tiles that have a character directly on either
+// side in the same row, i.e. "HE█LO", which usually means a color code was
+// used where a letter was meant. Color tiles next to blanks or the edge of the
+// board are allowed. The suspicious cells are returned in a
+// *ColorInTextError.
+func ValidateText(l Layout) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	var cells []Cell
+	for x, row := range l {
+		for y := 0; y < len(row); {
+			if !Color(row[y]).valid() {
+				y++
+				continue
+			}
+
+			// Find the end of this run of color tiles.
+			end := y
+			for end < len(row) && Color(row[end]).valid() {
+				end++
+			}
+			if y > 0 && isText(row[y-1]) && end < len(row) && isText(row[end]) {
+				for col := y; col < end; col++ {
+					cells = append(cells, Cell{Row: x, Col: col})
+				}
+			}
+			y = end
+		}
+	}
+
+	if len(cells) > 0 {
+		return &ColorInTextError{Cells: cells}
+	}
+	return nil
+}
+
+// isText returns true for codes of characters other than the blank.
+func isText(code int) bool {
+	_, ok := DecodeCode(code)
+	return ok && code != CodeBlank
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateText(t *testing.T) {
+	t.Parallel()
+
+	ok := NewLayout()
+	ok.Print(0, 0, "RED")
+	ok.SetColor(0, 4, ColorRed)
+	ok.SetColor(1, 0, ColorBlue)
+	ok.Print(1, 1, "BLUE")
+	ok.SetColor(2, 21, ColorGreen)
+	if err := ValidateText(ok); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	bad := NewLayout()
+	bad.Print(0, 0, "HELLO")
+	bad.SetColor(0, 2, ColorRed)
+	bad.Print(3, 5, "AB  CD")
+	bad.SetColor(3, 6, ColorWhite)
+	bad.SetColor(3, 7, ColorWhite)
+	bad.SetColor(3, 8, ColorWhite)
+
+	err := ValidateText(bad)
+	if !errors.Is(err, ErrColorInText) {
+		t.Fatalf("expected ErrColorInText, got: %v", err)
+	}
+	var cellErr *ColorInTextError
+	if !errors.As(err, &cellErr) {
+		t.Fatalf("expected ColorInTextError, got: %T", err)
+	}
+	want := []Cell{{0, 2}, {3, 6}, {3, 7}, {3, 8}}
+	if !reflect.DeepEqual(cellErr.Cells, want) {
+		t.Errorf("wrong cells, want: %v, got: %v", want, cellErr.Cells)
+	}
+
+	bad[0][0] = 99
+	if err := ValidateText(bad); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("expected ErrInvalidCode, got: %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVBML = errors.New("invalid VBML")
+
+type vbmlDocument struct {
+	Components []vbmlComponent `json:"components"`
+}
+
+type vbmlComponent struct {
+	Template string    `json:"template"`
+	Style    vbmlStyle `json:"style"`
+}
+
+type vbmlStyle struct {
+	Justify          string        `json:"justify"`
+	Align            string        `json:"align"`
+	Width            int           `json:"width"`
+	Height           int           `json:"height"`
+	AbsolutePosition *vbmlPosition `json:"absolutePosition"`
+}
+
+type vbmlPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// vbmlCell is a single character of a template. Spaces are tracked separately
+// from blank codes so that only literal spaces break words.
+type vbmlCell struct {
+	code  int
+	space bool
+}
+
+// ParseVBML renders Vestaboard Markup Language onto a layout. The input is
+// either a bare template or a JSON document with components. Templates may
+// contain color names or character codes in braces, like {red} or {63}, and
+// newlines for explicit line breaks.
+//
+// Components are placed at their absolutePosition if set, otherwise they are
+// stacked from the top of the board. Stacked components without a height
+// share the rows that the others leave free equally, the first ones get the
+// rows that don't divide evenly. Style supports justify (left, center,
+// right), align (top, center, bottom), width, and height.
+func ParseVBML(vbml string) (Layout, error) {
+	doc := vbmlDocument{
+		Components: []vbmlComponent{{Template: vbml}},
+	}
+	if trimmed := strings.TrimSpace(vbml); strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		doc = vbmlDocument{}
+		if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+			return NewLayout(), fmt.Errorf("%w: %v", ErrInvalidVBML, err)
+		}
+	}
+
+	shares := stackedHeights(doc.Components)
+	l := NewLayout()
+	nextRow := 0
+	for i, c := range doc.Components {
+		height, err := c.render(&l, nextRow, shares[i])
+		if err != nil {
+			return NewLayout(), fmt.Errorf("component %d: %w", i, err)
+		}
+		if c.Style.AbsolutePosition == nil {
+			nextRow += height
+		}
+	}
+	if err := l.Validate(); err != nil {
+		return NewLayout(), err
+	}
+	return l, nil
+}
+
+// stackedHeights returns the height of each stacked component without a
+// height of its own, sharing the rows that are left free equally.
+func stackedHeights(components []vbmlComponent) []int {
+	free, unsized := Rows, 0
+	for _, c := range components {
+		if c.Style.AbsolutePosition != nil {
+			continue
+		}
+		if c.Style.Height > 0 {
+			free -= c.Style.Height
+		} else {
+			unsized++
+		}
+	}
+	if free < 0 {
+		free = 0
+	}
+
+	shares := make([]int, len(components))
+	n := 0
+	for i, c := range components {
+		if c.Style.AbsolutePosition != nil || c.Style.Height > 0 {
+			continue
+		}
+		shares[i] = free / unsized
+		if n < free%unsized {
+			shares[i]++
+		}
+		n++
+	}
+	return shares
+}
+
+// render draws the component and returns the number of rows it occupies. A
+// stacked component without a height gets share rows.
+func (c *vbmlComponent) render(l *Layout, nextRow, share int) (int, error) {
+	s := c.Style
+	row, col := nextRow, 0
+	if p := s.AbsolutePosition; p != nil {
+		row, col = p.Y, p.X
+	}
+	width, height := s.Width, s.Height
+	if width == 0 {
+		width = Columns - col
+	}
+	if height == 0 {
+		height = Rows - row
+		if s.AbsolutePosition == nil {
+			height = share
+		}
+	}
+	if row < 0 || col < 0 || width < 0 || height < 0 || row+height > Rows || col+width > Columns {
+		return 0, fmt.Errorf("%dx%d at row %d, column %d doesn't fit on the board: %w",
+			width, height, row, col, ErrInvalidCoordinate)
+	}
+
+	justify, err := vbmlJustify(s.Justify)
+	if err != nil {
+		return 0, err
+	}
+
+	lines, err := parseVBMLTemplate(c.Template)
+	if err != nil {
+		return 0, err
+	}
+	var wrapped [][]vbmlCell
+	for _, line := range lines {
+		w, err := wrapCells(line, width)
+		if err != nil {
+			return 0, err
+		}
+		wrapped = append(wrapped, w...)
+	}
+	if len(wrapped) > height {
+		return 0, fmt.Errorf("template needs %d rows, the component has %d: %w", len(wrapped), height, ErrMessageTruncated)
+	}
+
+	top := 0
+	switch strings.ToLower(s.Align) {
+	case "", "center", "middle":
+		top = (height - len(wrapped)) / 2
+	case "top":
+	case "bottom":
+		top = height - len(wrapped)
+	default:
+		return 0, fmt.Errorf("unknown align %q: %w", s.Align, ErrInvalidVBML)
+	}
+
+	for i, line := range wrapped {
+		start := 0
+		switch justify {
+		case AlignCenter:
+			start = (width - len(line)) / 2
+		case AlignRight:
+			start = width - len(line)
+		}
+		for j, cell := range line {
+			l[row+top+i][col+start+j] = cell.code
+		}
+	}
+	return height, nil
+}
+
+func vbmlJustify(s string) (Align, error) {
+	switch strings.ToLower(s) {
+	case "", "center":
+		return AlignCenter, nil
+	case "left":
+		return AlignLeft, nil
+	case "right":
+		return AlignRight, nil
+	}
+	return AlignCenter, fmt.Errorf("unknown justify %q: %w", s, ErrInvalidVBML)
+}
+
+// parseVBMLTemplate converts a template into lines of cells.
+func parseVBMLTemplate(tmpl string) ([][]vbmlCell, error) {
+	runes := []rune(tmpl)
+	lines := [][]vbmlCell{nil}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '\n':
+			lines = append(lines, nil)
+			continue
+		case '}':
+			return nil, fmt.Errorf("unbalanced '}' at position %d: %w", i, ErrInvalidVBML)
+		case '{':
+			end := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == '{' {
+					break
+				}
+				if runes[j] == '}' {
+					end = j
+					break
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("unbalanced '{' at position %d: %w", i, ErrInvalidVBML)
+			}
+			code, err := vbmlCode(string(runes[i+1 : end]))
+			if err != nil {
+				return nil, fmt.Errorf("position %d: %w", i, err)
+			}
+			lines[len(lines)-1] = append(lines[len(lines)-1], vbmlCell{code: code})
+			i = end
+			continue
+		}
+
+		code, ok := EncodeRune(r)
+		if !ok {
+			return nil, fmt.Errorf("invalid character %q at position %d: %w", string(r), i, ErrInvalidCharacter)
+		}
+		lines[len(lines)-1] = append(lines[len(lines)-1], vbmlCell{code: code, space: r == ' '})
+	}
+	return lines, nil
+}
+
+// vbmlCode resolves the contents of a {} tag, either a code or a color name.
+func vbmlCode(name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if n, err := strconv.Atoi(name); err == nil {
+		if !ValidCode(n) {
+			return 0, fmt.Errorf("{%s}: %w", name, ErrInvalidCode)
+		}
+		return n, nil
+	}
+	if strings.EqualFold(name, "blank") {
+		return CodeBlank, nil
+	}
+	c, err := ParseColor(name)
+	if err != nil {
+		return 0, fmt.Errorf("{%s}: %w", name, err)
+	}
+	return c.Code(), nil
+}
+
+// wrapCells greedily wraps a line of cells on spaces.
+func wrapCells(line []vbmlCell, width int) ([][]vbmlCell, error) {
+	var words [][]vbmlCell
+	var cur []vbmlCell
+	for _, c := range line {
+		if c.space {
+			if len(cur) > 0 {
+				words = append(words, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, c)
+	}
+	if len(cur) > 0 {
+		words = append(words, cur)
+	}
+
+	var lines [][]vbmlCell
+	var out []vbmlCell
+	for _, w := range words {
+		if len(w) > width {
+			return nil, fmt.Errorf("word of %d characters, the maximum is %d: %w", len(w), width, ErrWordTooLong)
+		}
+		if len(out) > 0 && len(out)+1+len(w) > width {
+			lines = append(lines, out)
+			out = nil
+		}
+		if len(out) > 0 {
+			out = append(out, vbmlCell{code: CodeBlank, space: true})
+		}
+		out = append(out, w...)
+	}
+	// Keep empty lines, they are explicit line breaks.
+	lines = append(lines, out)
+	return lines, nil
+}
+
+// SendVBML renders VBML and sends it to the board.
+func (c *RWClient) SendVBML(ctx context.Context, vbml string) (*MessageResponse, error) {
+	l, err := ParseVBML(vbml)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendMessage(ctx, l)
+}
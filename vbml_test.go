@@ -0,0 +1,133 @@
+// Copyright 2021 Mike Helmick
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vestaboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseVBML(t *testing.T) {
+	t.Parallel()
+
+	l, err := ParseVBML("{red}{63} alert {green}\nall good")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Two lines, centered vertically on rows 2 and 3.
+	want := Layout{}
+	want.Print(2, 6, "   ALERT")
+	want[2][6], want[2][7], want[2][15] = int(ColorRed), int(ColorRed), int(ColorGreen)
+	want.Print(3, 7, "ALL GOOD")
+	if l != want {
+		t.Errorf("wrong layout, want:\n%s\ngot:\n%s", Decode(want), Decode(l))
+	}
+}
+
+func TestParseVBMLComponents(t *testing.T) {
+	t.Parallel()
+
+	doc := `{"components": [
+		{"template": "top left", "style": {"justify": "left", "align": "top", "height": 1}},
+		{"template": "{blue}{blue}", "style": {"justify": "right", "height": 1}},
+		{"template": "72°", "style": {"width": 4, "height": 1, "absolutePosition": {"x": 18, "y": 5}}}
+	]}`
+	l, err := ParseVBML(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rowText(l, 0); got != "TOP LEFT"+strings.Repeat(" ", Columns-8) {
+		t.Errorf("wrong row 0: %q", got)
+	}
+	if l[1][20] != int(ColorBlue) || l[1][21] != int(ColorBlue) {
+		t.Errorf("wrong row 1: %v", l[1])
+	}
+	if got := rowText(l, 5); got != strings.Repeat(" ", 18)+"72° " {
+		t.Errorf("wrong row 5: %q", got)
+	}
+}
+
+func TestParseVBMLStackedUnsized(t *testing.T) {
+	t.Parallel()
+
+	center := func(s string) string {
+		left := (Columns - len(s)) / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", Columns-left-len(s))
+	}
+	blank := strings.Repeat(" ", Columns)
+
+	cases := []struct {
+		name string
+		doc  string
+		want [Rows]string
+	}{
+		{
+			// Each gets 3 rows and is centered in them.
+			name: "equal_share",
+			doc:  `{"components": [{"template": "top"}, {"template": "bottom"}]}`,
+			want: [Rows]string{blank, center("TOP"), blank, blank, center("BOTTOM"), blank},
+		},
+		{
+			// 5 free rows are split 3 and 2.
+			name: "uneven_share",
+			doc: `{"components": [{"template": "a", "style": {"align": "bottom"}},
+				{"template": "header", "style": {"height": 1}},
+				{"template": "b", "style": {"align": "bottom"}}]}`,
+			want: [Rows]string{blank, blank, center("A"), center("HEADER"), blank, center("B")},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l, err := ParseVBML(tc.doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for row, want := range tc.want {
+				if got := rowText(l, row); got != want {
+					t.Errorf("row %d: want: %q, got: %q", row, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseVBMLErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in      string
+		wantErr error
+	}{
+		{in: "{red", wantErr: ErrInvalidVBML},
+		{in: "red}", wantErr: ErrInvalidVBML},
+		{in: "{re{d}", wantErr: ErrInvalidVBML},
+		{in: "{chartreuse}", wantErr: ErrInvalidColor},
+		{in: "{43}", wantErr: ErrInvalidCode},
+		{in: "tab\t", wantErr: ErrInvalidCharacter},
+		{in: "1\n2\n3\n4\n5\n6\n7", wantErr: ErrMessageTruncated},
+		{in: `{"components": [{"template": "x", "style": {"justify": "sideways"}}]}`, wantErr: ErrInvalidVBML},
+		{in: `{"components": [{"template": "x", "style": {"width": 5, "absolutePosition": {"x": 20, "y": 0}}}]}`, wantErr: ErrInvalidCoordinate},
+	}
+	for _, tc := range cases {
+		if _, err := ParseVBML(tc.in); !errors.Is(err, tc.wantErr) {
+			t.Errorf("ParseVBML(%q), want: %v, got: %v", tc.in, tc.wantErr, err)
+		}
+	}
+}
@@ -16,3 +16,14 @@
 //
 // This is an unofficial client library.
 package vestaboard
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Version is the version of this library.
+const Version = "0.1.0"
+
+// DefaultUserAgent is the User-Agent header sent unless WithUserAgent is used.
+var DefaultUserAgent = fmt.Sprintf("go-vestaboard/%s (%s)", Version, runtime.Version())
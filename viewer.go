@@ -44,8 +44,6 @@ func (c *Client) Viewer(ctx context.Context) (*ViewerResponse, error) {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set(APIKeyHeader, c.apiKey)
-	req.Header.Set(APIKeySecret, c.apiSecret)
 
 	var response ViewerResponse
 	_, err = c.do(req, &response)